@@ -20,29 +20,20 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 // SOFTWARE.
 
-// Oanda-Go package is a wrapper API for [Oanda-V20] RESTful API.
-// Currently this wrapper API only covers two endpoints:
-//
-//  1. Get request for [Instrument - candles endpoint] which returns
-//     historical OHLC Bid/Ask.
-//
-//     - Parameters requires instrument symbol, token, and granularity (i.e., 'S5' for 5 second candles)
-//
-//  2. Get JSON Stream for [Pricing - stream endpoint]
-//     which returns live Bid/Ask.
-//
-//     - Parameters requires list of instruments, token, and id
+// Oanda-Go is an example of querying Oanda's [Oanda-V20] RESTful API with
+// the oanda package, which wraps its account, instrument, order, trade,
+// position, transaction and pricing endpoints. It fetches candles and the
+// details of the account named by the -account flag from res.json.
 //
 // Don't forget to check Oanda's [Best Practices] before querying any
 // of their endpoints.
 //
 // [Oanda-V20]: https://developer.oanda.com/rest-live-v20/introduction/
-// [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
-// [Pricing - stream endpoint]: https://developer.oanda.com/rest-live-v20/pricing-ep/
 // [Best Practices]: https://developer.oanda.com/rest-live-v20/best-practices/
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -113,8 +104,8 @@ func main() {
 
 	changes, err := oanda.GetAccountChanges(accounts.Account[0].ID, "256", token) // accounts.Account[0].ID
 	if err != nil {
-		checkErr, ok := err.(*oanda.ErrorMsg)
-		if ok {
+		var checkErr *oanda.ErrorMsg
+		if errors.As(err, &checkErr) {
 			if !checkErr.Empty() {
 				log.Print("could not handle request to changes url")
 			}
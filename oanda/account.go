@@ -1,12 +1,9 @@
 package oanda
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"time"
+	"net/url"
 )
 
 /*
@@ -50,85 +47,122 @@ embedded struct for AccountID
 endpoint: /v3/accounts/{accountID}
 */
 type IdDetails struct {
-	GuaranteedStopLossOrderMode string        `json:"guaranteedStopLossOrderMode"`
-	HedgingEnabled              bool          `json:"hedgingEnabled"`
-	ID                          string        `json:"id"`
-	CreatedTime                 string        `json:"createdTime"`
-	Currency                    string        `json:"currency"`
-	CreatedByUserID             int           `json:"createdByUserID"`
-	Alias                       string        `json:"alias"`
-	MarginRate                  string        `json:"marginRate"`
-	LastTransactionID           string        `json:"lastTransactionID"`
-	Balance                     string        `json:"balance"`
-	OpenTradeCount              int           `json:"openTradeCount"`
-	OpenPositionCount           int           `json:"openPositionCount"`
-	PendingOrderCount           int           `json:"pendingOrderCount"`
-	PL                          string        `json:"pl"`
-	ResettablePL                string        `json:"resettablePL"`
-	ResettablePLTime            string        `json:"resettablePLTime"`
-	Financing                   string        `json:"financing"`
-	Commission                  string        `json:"commission"`
-	DividendAdjustment          string        `json:"dividendAdjustment"`
-	GuaranteedExecutionFees     string        `json:"guaranteedExecutionFees"`
-	Orders                      []string      `json:"orders"`
-	Positions                   []PositionsID `json:"positions"`
-	Trades                      []string      `json:"trades"`
-	UnrealizedPL                string        `json:"unrealizedPL"`
-	NAV                         string        `json:"NAV"`
-	MarginUsed                  string        `json:"marginUsed"`
-	MarginAvailable             string        `json:"marginAvailable"`
-	PositionValue               string        `json:"positionValue"`
-	MarginCloseoutUnrealizedPL  string        `json:"marginCloseoutUnrealizedPL"`
-	MarginCloseoutNAV           string        `json:"marginCloseoutNAV"`
-	MarginCloseoutMarginUsed    string        `json:"marginCloseoutMarginUsed"`
-	MarginCloseoutPositionValue string        `json:"marginCloseoutPositionValue"`
-	MarginCloseoutPercent       string        `json:"marginCloseoutPercent"`
-	WithdrawalLimit             string        `json:"withdrawalLimit"`
-	MarginCallMarginUsed        string        `json:"marginCallMarginUsed"`
-	MarginCallPercent           string        `json:"marginCallPercent"`
+	GuaranteedStopLossOrderMode string         `json:"guaranteedStopLossOrderMode"`
+	HedgingEnabled              bool           `json:"hedgingEnabled"`
+	ID                          string         `json:"id"`
+	CreatedTime                 Time           `json:"createdTime"`
+	Currency                    string         `json:"currency"`
+	CreatedByUserID             int            `json:"createdByUserID"`
+	Alias                       string         `json:"alias"`
+	MarginRate                  string         `json:"marginRate"`
+	LastTransactionID           string         `json:"lastTransactionID"`
+	Balance                     string         `json:"balance"`
+	OpenTradeCount              int            `json:"openTradeCount"`
+	OpenPositionCount           int            `json:"openPositionCount"`
+	PendingOrderCount           int            `json:"pendingOrderCount"`
+	PL                          string         `json:"pl"`
+	ResettablePL                string         `json:"resettablePL"`
+	ResettablePLTime            Time           `json:"resettablePLTime"`
+	Financing                   string         `json:"financing"`
+	Commission                  string         `json:"commission"`
+	DividendAdjustment          string         `json:"dividendAdjustment"`
+	GuaranteedExecutionFees     string         `json:"guaranteedExecutionFees"`
+	Orders                      []Order        `json:"-"`
+	Positions                   []PositionsID  `json:"positions"`
+	Trades                      []TradeSummary `json:"trades"`
+	UnrealizedPL                string         `json:"unrealizedPL"`
+	NAV                         string         `json:"NAV"`
+	MarginUsed                  string         `json:"marginUsed"`
+	MarginAvailable             string         `json:"marginAvailable"`
+	PositionValue               string         `json:"positionValue"`
+	MarginCloseoutUnrealizedPL  string         `json:"marginCloseoutUnrealizedPL"`
+	MarginCloseoutNAV           string         `json:"marginCloseoutNAV"`
+	MarginCloseoutMarginUsed    string         `json:"marginCloseoutMarginUsed"`
+	MarginCloseoutPositionValue string         `json:"marginCloseoutPositionValue"`
+	MarginCloseoutPercent       string         `json:"marginCloseoutPercent"`
+	WithdrawalLimit             string         `json:"withdrawalLimit"`
+	MarginCallMarginUsed        string         `json:"marginCallMarginUsed"`
+	MarginCallPercent           string         `json:"marginCallPercent"`
+}
+
+func (d *IdDetails) UnmarshalJSON(data []byte) error {
+	// idDetails has the same fields without the UnmarshalJSON method
+	type idDetails IdDetails
+	var raw struct {
+		idDetails
+		Orders []json.RawMessage `json:"orders"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	orders, err := unmarshalOrders(raw.Orders)
+	if err != nil {
+		return err
+	}
+
+	*d = IdDetails(raw.idDetails)
+	d.Orders = orders
+	return nil
 }
 
 /*
-embedded struct for AccountID
+embedded struct for AccountID, also returned by the [Position Endpoints]
 
 endpoint: /v3/accounts/{accountID}
+
+[Position Endpoints]: https://developer.oanda.com/rest-live-v20/position-ep/
 */
 type PositionsID struct {
-	Instrument string `json:"instrument"`
-	Long       Long   `json:"long"`
-	Short      Short  `json:"short"`
+	Instrument              string `json:"instrument"`
+	PL                      string `json:"pl,omitempty"`
+	UnrealizedPL            string `json:"unrealizedPL,omitempty"`
+	MarginUsed              string `json:"marginUsed,omitempty"`
+	ResettablePL            string `json:"resettablePL,omitempty"`
+	Financing               string `json:"financing,omitempty"`
+	Commission              string `json:"commission,omitempty"`
+	DividendAdjustment      string `json:"dividendAdjustment,omitempty"`
+	GuaranteedExecutionFees string `json:"guaranteedExecutionFees,omitempty"`
+	Long                    Long   `json:"long"`
+	Short                   Short  `json:"short"`
 }
 
 /*
-embedded struct for AccountID
+embedded struct for PositionsID, the long side of a position
 
 endpoint: /v3/accounts/{accountID}
 */
 type Long struct {
-	Instrument              string `json:"instrument"`
-	Units                   string `json:"units"`
-	PL                      string `json:"pl"`
-	ResettablePL            string `json:"resettablePL"`
-	Financing               string `json:"financing"`
-	DividendAdjustment      string `json:"dividendAdjustment"`
-	GuaranteedExecutionFees string `json:"guaranteedExecutionFees"`
-	UnrealizedPL            string `json:"unrealizedPL"`
+	Instrument              string   `json:"instrument"`
+	Units                   string   `json:"units"`
+	AveragePrice            string   `json:"averagePrice,omitempty"`
+	TradeIDs                []string `json:"tradeIDs,omitempty"`
+	PL                      string   `json:"pl"`
+	ResettablePL            string   `json:"resettablePL"`
+	Financing               string   `json:"financing"`
+	DividendAdjustment      string   `json:"dividendAdjustment"`
+	GuaranteedExecutionFees string   `json:"guaranteedExecutionFees"`
+	UnrealizedPL            string   `json:"unrealizedPL"`
+	MarginUsed              string   `json:"marginUsed,omitempty"`
 }
 
 /*
-embedded struct for AccountID
+embedded struct for PositionsID, the short side of a position
 
 endpoint: /v3/accounts/{accountID}
 */
 type Short struct {
-	Instrument              string `json:"instrument"`
-	Units                   string `json:"units"`
-	PL                      string `json:"pl"`
-	ResettablePL            string `json:"resettablePL"`
-	Financing               string `json:"financing"`
-	DividendAdjustment      string `json:"dividendAdjustment"`
-	GuaranteedExecutionFees string `json:"guaranteedExecutionFees"`
-	UnrealizedPL            string `json:"unrealizedPL"`
+	Instrument              string   `json:"instrument"`
+	Units                   string   `json:"units"`
+	AveragePrice            string   `json:"averagePrice,omitempty"`
+	TradeIDs                []string `json:"tradeIDs,omitempty"`
+	PL                      string   `json:"pl"`
+	ResettablePL            string   `json:"resettablePL"`
+	Financing               string   `json:"financing"`
+	DividendAdjustment      string   `json:"dividendAdjustment"`
+	GuaranteedExecutionFees string   `json:"guaranteedExecutionFees"`
+	UnrealizedPL            string   `json:"unrealizedPL"`
+	MarginUsed              string   `json:"marginUsed,omitempty"`
 }
 
 /*
@@ -150,11 +184,11 @@ embedded struct for AccountSummary
 endpoint: /v3/accounts/{accountID}/summary
 */
 type SummaryDetails struct {
-	NAV                         string `json:"account"`
+	NAV                         string `json:"NAV"`
 	Alias                       string `json:"alias"`
 	Balance                     string `json:"balance"`
 	CreatedByUserID             int    `json:"createdByUserID"`
-	CreatedTime                 string `json:"createdTime"`
+	CreatedTime                 Time   `json:"createdTime"`
 	Currency                    string `json:"currency"`
 	HedgingEnabled              bool   `json:"hedgingEnabled"`
 	ID                          string `json:"id"`
@@ -198,20 +232,48 @@ embedded struct for AccountInstru
 endpoint: /v3/accounts/{accountID}/instruments
 */
 type InstruDetails struct {
-	Name                        string       `json:"name"`
-	Type                        string       `json:"type"`
-	DisplayName                 string       `json:"displayName"`
-	PipLocation                 int          `json:"pipLocation"`
-	DisplayPrecision            int          `json:"displayPrecision"`
-	TradeUnitsPrecision         int          `json:"tradeUnitsPrecision"`
-	MinimumTradeSize            string       `json:"minimumTradeSize"`
-	MaximumTrailingStopDistance string       `json:"maximumTrailingStopDistance"`
-	MinimumTrailingStopDistance string       `json:"minimumTrailingStopDistance"`
-	MaximumPositionSize         string       `json:"maximumPositionSize"`
-	MaximumOrderUnits           string       `json:"maximumOrderUnits"`
-	MarginRate                  string       `json:"marginRate"`
-	GuaranteedStopLossOrderMode string       `json:"guaranteedStopLossOrderMode"`
-	Tags                        []InstruTags `json:"tags"`
+	Name                                    string                                   `json:"name"`
+	Type                                    string                                   `json:"type"`
+	DisplayName                             string                                   `json:"displayName"`
+	PipLocation                             int                                      `json:"pipLocation"`
+	DisplayPrecision                        int                                      `json:"displayPrecision"`
+	TradeUnitsPrecision                     int                                      `json:"tradeUnitsPrecision"`
+	MinimumTradeSize                        string                                   `json:"minimumTradeSize"`
+	MaximumTrailingStopDistance             string                                   `json:"maximumTrailingStopDistance"`
+	MinimumGuaranteedStopLossDistance       string                                   `json:"minimumGuaranteedStopLossDistance"`
+	MinimumTrailingStopDistance             string                                   `json:"minimumTrailingStopDistance"`
+	MaximumPositionSize                     string                                   `json:"maximumPositionSize"`
+	MaximumOrderUnits                       string                                   `json:"maximumOrderUnits"`
+	MarginRate                              string                                   `json:"marginRate"`
+	Commission                              InstruCommission                         `json:"commission"`
+	GuaranteedStopLossOrderMode             string                                   `json:"guaranteedStopLossOrderMode"`
+	GuaranteedStopLossOrderExecutionPremium string                                   `json:"guaranteedStopLossOrderExecutionPremium"`
+	GuaranteedStopLossOrderLevelRestriction InstruGuaranteedStopLossLevelRestriction `json:"guaranteedStopLossOrderLevelRestriction"`
+	Financing                               InstruFinancing                          `json:"financing"`
+	Tags                                    []InstruTags                             `json:"tags"`
+}
+
+/*
+embedded struct for AccountInstru, the commission charged for trading the
+instrument, Commission per UnitsTraded units and at least MinimumCommission
+
+endpoint: /v3/accounts/{accountID}/instruments
+*/
+type InstruCommission struct {
+	Commission        string `json:"commission"`
+	UnitsTraded       string `json:"unitsTraded"`
+	MinimumCommission string `json:"minimumCommission"`
+}
+
+/*
+embedded struct for AccountInstru, the total Volume of guaranteed stop loss
+orders allowed within PriceRange of each other
+
+endpoint: /v3/accounts/{accountID}/instruments
+*/
+type InstruGuaranteedStopLossLevelRestriction struct {
+	Volume     string `json:"volume"`
+	PriceRange string `json:"priceRange"`
 }
 
 /*
@@ -242,7 +304,7 @@ endpoint: /v3/accounts/{accountID}/instruments
 */
 type InstruDaysOfWeek struct {
 	DayOfWeek   string `json:"dayOfWeek"`
-	DaysCharged string `json:"daysCharged"`
+	DaysCharged int    `json:"daysCharged"`
 }
 
 /*
@@ -260,117 +322,105 @@ type AccountChange struct {
 }
 
 /*
-embedded struct for AccountChange
+embedded struct for AccountChange, the orders, trades and positions which
+changed since the requested transaction and the transactions which changed them
 
 endpoint: /v3/accounts/{accountID}/changes
 */
 type Changes struct {
-	OrdersCancelled []string           `json:"ordersCancelled,omitempty"` // incomplete, wrong type
-	OrdersCreated   []string           `json:"ordersCreated,omitempty"`   // incomplete, wrong type
-	OrdersFilled    []OrdersFilled     `json:"ordersFilled,omitempty"`
-	OrdersTriggered []string           `json:"ordersTriggered,omitempty"` // incomplete, wrong type
-	Positions       []ChangesPositions `json:"positions,omitempty"`
-	TradesClosed    []string           `json:"tradesClosed,omitempty"` // incomplete, wrong type
-	TradesOpened    []struct {
-		CurrentUnits string `json:"currentUnits"`
-		Financing    string `json:"financing"`
-		ID           string `json:"id"`
-		InitialUnits string `json:"initialUnits"`
-		Instrument   string `json:"instrument"`
-		OpenTime     string `json:"openTime"`
-		Price        string `json:"price"`
-		RealizedPL   string `json:"realizedPL"`
-		State        string `json:"state"`
-	} `json:"tradesOpened,omitempty"`
-	TradesReduced []string `json:"tradesReduced,omitempty"` // incomplete, wrong type
-	Transactions  []struct {
-		AccountBalance string `json:"accountBalance,omitempty"`
-		AccountID      string `json:"accountID,omitempty"`
-		BatchID        string `json:"batchID,omitempty"`
-		Financing      string `json:"financing,omitempty"`
-		ID             string `json:"id,omitempty"`
-		Instrument     string `json:"instrument,omitempty"`
-		PositionFill   string `json:"positionFill,omitempty"`
-		Reason         string `json:"reason,omitempty"`
-		Time           string `json:"time,omitempty"`
-		TimeInForce    string `json:"timeInForce,omitempty"`
-		TradeOpened    []struct {
-			TradeID string `json:"tradeID"`
-			Units   string `json:"units"`
-		} `json:"tradeOpened,omitempty"`
-		Type   string `json:"type"`
-		Units  string `json:"units"`
-		UserID string `json:"userID"`
-	} `json:"transactions,omitempty"`
-}
-
-/*
-embedded struct for AccountChange
+	OrdersCreated   []Order        `json:"-"`
+	OrdersCancelled []Order        `json:"-"`
+	OrdersFilled    []Order        `json:"-"`
+	OrdersTriggered []Order        `json:"-"`
+	TradesOpened    []TradeSummary `json:"tradesOpened,omitempty"`
+	TradesReduced   []TradeSummary `json:"tradesReduced,omitempty"`
+	TradesClosed    []TradeSummary `json:"tradesClosed,omitempty"`
+	Positions       []PositionsID  `json:"positions,omitempty"`
+	Transactions    []Transaction  `json:"-"`
+}
 
-endpoint: /v3/accounts/{accountID}/changes
-*/
-type ChangesPositions struct {
-	Instrument string `json:"instrument"`
-	Long       struct {
-		AveragePrice string   `json:"averagePrice,omitempty"`
-		PL           string   `json:"pl"`
-		ResettablePL string   `json:"resettablePL"`
-		TradeIDs     []string `json:"tradeIDs,omitempty"`
-		Units        string   `json:"units"`
-	} `json:"long,omitempty"`
-	PL           string `json:"pl"`
-	ResettablePL string `json:"resettablePL"`
-	Short        struct {
-		AveragePrice string   `json:"averagePrice,omitempty"`
-		PL           string   `json:"pl"`
-		ResettablePL string   `json:"resettablePL"`
-		TradeIDs     []string `json:"tradeIDs,omitempty"`
-		Units        string   `json:"units"`
-	} `json:"short,omitempty"`
-}
-
-/*
-embedded struct for AccountChange
+func (ch *Changes) UnmarshalJSON(data []byte) error {
+	// changes has the same fields without the UnmarshalJSON method
+	type changes Changes
+	var raw struct {
+		changes
+		OrdersCreated   []json.RawMessage `json:"ordersCreated"`
+		OrdersCancelled []json.RawMessage `json:"ordersCancelled"`
+		OrdersFilled    []json.RawMessage `json:"ordersFilled"`
+		OrdersTriggered []json.RawMessage `json:"ordersTriggered"`
+		Transactions    []json.RawMessage `json:"transactions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*ch = Changes(raw.changes)
+
+	var err error
+	if ch.OrdersCreated, err = unmarshalOrders(raw.OrdersCreated); err != nil {
+		return err
+	}
+	if ch.OrdersCancelled, err = unmarshalOrders(raw.OrdersCancelled); err != nil {
+		return err
+	}
+	if ch.OrdersFilled, err = unmarshalOrders(raw.OrdersFilled); err != nil {
+		return err
+	}
+	if ch.OrdersTriggered, err = unmarshalOrders(raw.OrdersTriggered); err != nil {
+		return err
+	}
+	if ch.Transactions, err = unmarshalTransactions(raw.Transactions); err != nil {
+		return err
+	}
+	return nil
+}
+
+/*
+embedded struct for AccountChange, the current values of an account's fields
+which change with the price
 
 endpoint: /v3/accounts/{accountID}/changes
 */
-type OrdersFilled struct {
-	CreateTime           string `json:"createTime"`
-	FilledTime           string `json:"filledTime"`
-	FillingTransactionID string `json:"fillingTransactionID"`
-	ID                   string `json:"id"`
-	Instrument           string `json:"instrument"`
-	PositionFill         string `json:"positionFill"`
-	State                string `json:"state"`
-	TimeInForce          string `json:"timeInForce"`
-	TradeOpenedID        string `json:"tradeOpenedID"`
-	Type                 string `json:"type"`
-	Units                string `json:"units"`
+type State struct {
+	NAV                         string              `json:"NAV"`
+	Balance                     string              `json:"balance,omitempty"`
+	PL                          string              `json:"pl,omitempty"`
+	ResettablePL                string              `json:"resettablePL,omitempty"`
+	Financing                   string              `json:"financing,omitempty"`
+	Commission                  string              `json:"commission,omitempty"`
+	DividendAdjustment          string              `json:"dividendAdjustment,omitempty"`
+	GuaranteedExecutionFees     string              `json:"guaranteedExecutionFees,omitempty"`
+	MarginAvailable             string              `json:"marginAvailable"`
+	MarginCloseoutMarginUsed    string              `json:"marginCloseoutMarginUsed"`
+	MarginCloseoutNAV           string              `json:"marginCloseoutNAV"`
+	MarginCloseoutPercent       string              `json:"marginCloseoutPercent"`
+	MarginCloseoutPositionValue string              `json:"marginCloseoutPositionValue,omitempty"`
+	MarginCloseoutUnrealizedPL  string              `json:"marginCloseoutUnrealizedPL"`
+	MarginCallMarginUsed        string              `json:"marginCallMarginUsed,omitempty"`
+	MarginCallPercent           string              `json:"marginCallPercent,omitempty"`
+	MarginUsed                  string              `json:"marginUsed"`
+	Orders                      []DynamicOrderState `json:"orders,omitempty"`
+	PositionValue               string              `json:"positionValue"`
+	Positions                   []StatePositions    `json:"positions,omitempty"`
+	Trades                      []StateTrades       `json:"trades,omitempty"`
+	UnrealizedPL                string              `json:"unrealizedPL"`
+	WithdrawalLimit             string              `json:"withdrawalLimit"`
 }
 
 /*
-embedded struct for AccountChange
+embedded struct for State, the price dependent fields of a pending order
 
 endpoint: /v3/accounts/{accountID}/changes
 */
-type State struct {
-	NAV                        string           `json:"NAV"`
-	MarginAvailable            string           `json:"marginAvailable"`
-	MarginCloseoutMarginUsed   string           `json:"marginCloseoutMarginUsed"`
-	MarginCloseoutNAV          string           `json:"marginCloseoutNAV"`
-	MarginCloseoutPercent      string           `json:"marginCloseoutPercent"`
-	MarginCloseoutUnrealizedPL string           `json:"marginCloseoutUnrealizedPL"`
-	MarginUsed                 string           `json:"marginUsed"`
-	Orders                     []string         `json:"orders,omitempty"` // incomplete, wrong type
-	PositionValue              string           `json:"positionValue"`
-	Positions                  []StatePositions `json:"positions,omitempty"` // incomplete, wrong type
-	Trades                     []StateTrades    `json:"trades,omitempty"`    // incomplete, wrong type
-	UnrealizedPL               string           `json:"unrealizedPL"`
-	WithdrawalLimit            string           `json:"withdrawalLimit"`
+type DynamicOrderState struct {
+	ID                     string `json:"id"`
+	TrailingStopValue      string `json:"trailingStopValue,omitempty"`
+	TriggerDistance        string `json:"triggerDistance,omitempty"`
+	IsTriggerDistanceExact bool   `json:"isTriggerDistanceExact,omitempty"`
 }
 
 /*
-embedded struct for AccountChange
+embedded struct for State, the price dependent fields of a position
 
 endpoint: /v3/accounts/{accountID}/changes
 */
@@ -379,22 +429,26 @@ type StatePositions struct {
 	LongUnrealizedPL  string `json:"longUnrealizedPL"`
 	NetUnrealizedPL   string `json:"netUnrealizedPL"`
 	ShortUnrealizedPL string `json:"shortUnrealizedPL"`
+	MarginUsed        string `json:"marginUsed,omitempty"`
 }
 
 /*
-embedded struct for AccountChange
+embedded struct for State, the price dependent fields of an open trade
 
 endpoint: /v3/accounts/{accountID}/changes
 */
 type StateTrades struct {
 	ID           string `json:"id"`
 	UnrealizedPL string `json:"unrealizedPL"`
+	MarginUsed   string `json:"marginUsed,omitempty"`
 }
 
 /*
-struct for unmarshalling error messages returned from Oanda's REST-V20 API
+struct for unmarshalling error messages returned from Oanda's REST-V20 API,
+see APIError for the full error returned by each endpoint.
 */
 type ErrorMsg struct {
+	ErrorCode    string `json:"errorCode,omitempty"`
 	ErrorMessage string `json:"errorMessage"`
 }
 
@@ -419,47 +473,26 @@ For more info go to Oandas documentation for [Account Endpoints].
 [Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
 */
 func GetAccounts(token string) (*AccountEndpoint, error) {
-	url := "https://api-fxpractice.oanda.com/v3/accounts"
-
-	// declare http client request, set to timeout after 10 seconds
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-
-	// preapre http get request with url
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
-
-	// set headers for get request
-	// check Oandas Best Practices for guidance https://developer.oanda.com/rest-live-v20/best-practices/
-	// and check their instrument endpoint for candles https://developer.oanda.com/rest-live-v20/instrument-ep/
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
-	// req.URL.RawQuery = req.URL.Query().Encode()
-	req.URL.Query().Encode()
+	return NewClient(WithToken(token)).GetAccounts()
+}
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		return nil, fmt.Errorf("400 error: %d", response.StatusCode)
-	}
-	defer response.Body.Close()
+/*
+GetAccounts method will get a list of all accounts the client is authorized to use.
 
-	// response body is []byte
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Fatalln(err)
-	}
+endpoint: /v3/accounts
+*/
+func (c *Client) GetAccounts() (*AccountEndpoint, error) {
+	return c.GetAccountsContext(context.Background())
+}
 
+/*
+GetAccountsContext method is the same as GetAccounts but the request is
+cancelled when ctx is done.
+*/
+func (c *Client) GetAccountsContext(ctx context.Context) (*AccountEndpoint, error) {
 	var account AccountEndpoint
-	err = json.Unmarshal(body, &account)
-
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	if err := c.get(ctx, "/v3/accounts", nil, &account); err != nil {
+		return nil, err
 	}
 
 	return &account, nil
@@ -475,47 +508,33 @@ For more info go to Oandas documentation for [Account Endpoints].
 [Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
 */
 func GetAccountID(id string, token string) (*AccountID, error) {
-	url := "https://api-fxpractice.oanda.com/v3/accounts/" + id
-
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
-	req.URL.Query().Encode()
+	return NewClient(WithToken(token), WithAccountID(id)).GetAccountID()
+}
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		return nil, fmt.Errorf("400 error: %d", response.StatusCode)
-	}
-	defer response.Body.Close()
+/*
+GetAccountID method will return full details for the client's account.
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Fatalln(err)
-	}
+endpoint: /v3/accounts/{accountID}
+*/
+func (c *Client) GetAccountID() (*AccountID, error) {
+	return c.GetAccountIDContext(context.Background())
+}
 
+/*
+GetAccountIDContext method is the same as GetAccountID but the request is
+cancelled when ctx is done.
+*/
+func (c *Client) GetAccountIDContext(ctx context.Context) (*AccountID, error) {
 	var accountid AccountID
-	err = json.Unmarshal(body, &accountid)
-
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	if err := c.get(ctx, c.accountPath(""), nil, &accountid); err != nil {
+		return nil, err
 	}
 
 	return &accountid, nil
 }
 
 /*
-GetAccountID function will return Get a summary for a single account that a
+GetAccountSummary function will return a summary for a single account that a
 client has access to.
 
 For more info go to Oandas documentation for [Account Endpoints].
@@ -523,47 +542,33 @@ For more info go to Oandas documentation for [Account Endpoints].
 [Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
 */
 func GetAccountSummary(id string, token string) (*AccountSummary, error) {
-	url := "https://api-fxpractice.oanda.com/v3/accounts/" + id + "/summary"
-
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
-	req.URL.Query().Encode()
+	return NewClient(WithToken(token), WithAccountID(id)).GetAccountSummary()
+}
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		return nil, fmt.Errorf("400 error: %d", response.StatusCode)
-	}
-	defer response.Body.Close()
+/*
+GetAccountSummary method will return a summary for the client's account.
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Fatalln(err)
-	}
+endpoint: /v3/accounts/{accountID}/summary
+*/
+func (c *Client) GetAccountSummary() (*AccountSummary, error) {
+	return c.GetAccountSummaryContext(context.Background())
+}
 
+/*
+GetAccountSummaryContext method is the same as GetAccountSummary but the request is
+cancelled when ctx is done.
+*/
+func (c *Client) GetAccountSummaryContext(ctx context.Context) (*AccountSummary, error) {
 	var accountsummary AccountSummary
-	err = json.Unmarshal(body, &accountsummary)
-
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	if err := c.get(ctx, c.accountPath("/summary"), nil, &accountsummary); err != nil {
+		return nil, err
 	}
 
 	return &accountsummary, nil
 }
 
 /*
-GetAccountID function will return a list of tradeable instruments for the given account.
+GetAccountInstru function will return a list of tradeable instruments for the given account.
 The list of tradeable instruments is dependent on the regulatory division that the account
 is located in, thus should be the same for all accounts owned by a single user.
 
@@ -572,102 +577,103 @@ For more info go to Oandas documentation for [Account Endpoints].
 [Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
 */
 func GetAccountInstru(id string, token string) (*AccountInstru, error) {
-	url := "https://api-fxpractice.oanda.com/v3/accounts/" + id + "/instruments"
-
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
-	req.URL.Query().Encode()
+	return NewClient(WithToken(token), WithAccountID(id)).GetAccountInstru()
+}
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		return nil, fmt.Errorf("400 error: %d", response.StatusCode)
-	}
-	defer response.Body.Close()
+/*
+GetAccountInstru method will return a list of tradeable instruments for the client's account.
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Fatalln(err)
-	}
+endpoint: /v3/accounts/{accountID}/instruments
+*/
+func (c *Client) GetAccountInstru() (*AccountInstru, error) {
+	return c.GetAccountInstruContext(context.Background())
+}
 
+/*
+GetAccountInstruContext method is the same as GetAccountInstru but the request is
+cancelled when ctx is done.
+*/
+func (c *Client) GetAccountInstruContext(ctx context.Context) (*AccountInstru, error) {
 	var accountInstru AccountInstru
-	err = json.Unmarshal(body, &accountInstru)
-
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	if err := c.get(ctx, c.accountPath("/instruments"), nil, &accountInstru); err != nil {
+		return nil, err
 	}
 
 	return &accountInstru, nil
 }
 
 /*
-GetAccountID function will return an account for its current state and changes since a specified transactionID.
+GetAccountChanges function will return an account for its current state and changes since a specified transactionID.
 
 For more info go to Oandas documentation for [Account Endpoints].
 
 [Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
 */
 func GetAccountChanges(id string, transactionID string, token string) (*AccountChange, error) {
-	url := "https://api-fxpractice.oanda.com/v3/accounts/" + id + "/changes"
+	return NewClient(WithToken(token), WithAccountID(id)).GetAccountChanges(transactionID)
+}
 
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
+/*
+GetAccountChanges method will return the client's account for its current state and
+changes since a specified transactionID.
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
+endpoint: /v3/accounts/{accountID}/changes
+*/
+func (c *Client) GetAccountChanges(transactionID string) (*AccountChange, error) {
+	return c.GetAccountChangesContext(context.Background(), transactionID)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
+/*
+GetAccountChangesContext method is the same as GetAccountChanges but the request is
+cancelled when ctx is done.
+*/
+func (c *Client) GetAccountChangesContext(ctx context.Context, transactionID string) (*AccountChange, error) {
 	// query parameters for get request
-	q := req.URL.Query()
+	q := url.Values{}
 	q.Add("sinceTransactionID", transactionID)
-	req.URL.RawQuery = q.Encode()
 
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			log.Fatalln(err)
-		}
-
-		var errorMsg ErrorMsg
-		err = json.Unmarshal(body, &errorMsg)
-		if err != nil {
-			return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
-		}
-
-		return nil, &ErrorMsg{ErrorMessage: errorMsg.ErrorMessage}
+	var accountChange AccountChange
+	if err := c.get(ctx, c.accountPath("/changes"), q, &accountChange); err != nil {
+		return nil, err
 	}
-	defer response.Body.Close()
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Fatalln(err)
-	}
+	return &accountChange, nil
+}
 
-	var accountChange AccountChange
-	err = json.Unmarshal(body, &accountChange)
+/*
+AccountConfiguration holds the client-configurable portions of an account
+to set with ConfigureAccount, empty fields are left unchanged.
+*/
+type AccountConfiguration struct {
+	// Alias is the client-assigned name of the account.
+	Alias string `json:"alias,omitempty"`
+	// MarginRate is the margin rate as a decimal, i.e. "0.05" for 20:1 leverage.
+	MarginRate string `json:"marginRate,omitempty"`
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+/*
+struct for unmarshalling the response from configuring an account
+*/
+type AccountConfigurationResponse struct {
+	ClientConfigureTransaction *ClientConfigureTransaction `json:"clientConfigureTransaction"`
+	LastTransactionID          string                      `json:"lastTransactionID"`
+}
+
+/*
+ConfigureAccount method will set the alias and/or margin rate for the client's
+account.
+
+If Oanda rejects the configuration the error is an *APIError, use its
+Transaction method to get the *ClientConfigureRejectTransaction with the
+reason it was rejected.
+
+endpoint: /v3/accounts/{accountID}/configuration
+*/
+func (c *Client) ConfigureAccount(ctx context.Context, config AccountConfiguration) (*AccountConfigurationResponse, error) {
+	var response AccountConfigurationResponse
+	if err := c.send(ctx, "PATCH", c.accountPath("/configuration"), config, &response); err != nil {
+		return nil, err
 	}
 
-	return &accountChange, nil
+	return &response, nil
 }
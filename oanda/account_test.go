@@ -1,8 +1,16 @@
 package oanda_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
 	"github.com/davidhintelmann/Oanda-Go/oanda"
 )
@@ -96,3 +104,96 @@ func Example_accountChanges() {
 	// print positions
 	fmt.Println(changes.Changes.Positions)
 }
+
+func TestConfigureAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/v3/accounts/101-001-1234567-001/configuration" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %v", err)
+		}
+		if len(body) != 1 || body["marginRate"] != "0.05" {
+			t.Errorf("body should only set marginRate to 0.05 but is: %v", body)
+		}
+
+		fmt.Fprint(w, `{"clientConfigureTransaction":{"id":"12","type":"CLIENT_CONFIGURE","marginRate":"0.05"},"lastTransactionID":"12"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	response, err := client.ConfigureAccount(context.Background(), oanda.AccountConfiguration{MarginRate: "0.05"})
+	if err != nil {
+		t.Fatalf("ConfigureAccount() produced an error: %v", err)
+	}
+	if tx := response.ClientConfigureTransaction; tx == nil || tx.MarginRate != "0.05" {
+		t.Errorf("ClientConfigureTransaction should set the margin rate to 0.05 but is: %+v", tx)
+	}
+}
+
+// roundTripFunc answers requests without sending them, so clients pointed
+// at Oanda's hosts can be tested.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestConfigureAccountLive(t *testing.T) {
+	var host string
+	client := oanda.NewClient(
+		oanda.WithEnvironment(oanda.Live),
+		oanda.WithAccountID("001-001-1234567-001"),
+		oanda.WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			host = r.URL.Host
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"clientConfigureTransaction":{"id":"12","type":"CLIENT_CONFIGURE","alias":"main"},"lastTransactionID":"12"}`)),
+				Request:    r,
+			}, nil
+		})}),
+	)
+
+	// configuring an account places no order, so it needs no WithLiveTrading
+	if _, err := client.ConfigureAccount(context.Background(), oanda.AccountConfiguration{Alias: "main"}); err != nil {
+		t.Fatalf("ConfigureAccount() produced an error: %v", err)
+	}
+	if host != "api-fxtrade.oanda.com" {
+		t.Errorf("request should be sent to the live host but was sent to: %q", host)
+	}
+}
+
+func TestConfigureAccountReject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+	"clientConfigureRejectTransaction": {"id": "13", "type": "CLIENT_CONFIGURE_REJECT", "marginRate": "5", "rejectReason": "MARGIN_RATE_INVALID"},
+	"lastTransactionID": "13",
+	"errorCode": "MARGIN_RATE_INVALID",
+	"errorMessage": "The margin rate provided is invalid"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	_, err := client.ConfigureAccount(context.Background(), oanda.AccountConfiguration{MarginRate: "5"})
+
+	var apiErr *oanda.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "MARGIN_RATE_INVALID" {
+		t.Fatalf("ConfigureAccount() should return *oanda.APIError but returned: %v", err)
+	}
+	tx, err := apiErr.Transaction()
+	if err != nil {
+		t.Fatalf("Transaction() produced an error: %v", err)
+	}
+	if reject, ok := tx.(*oanda.ClientConfigureRejectTransaction); !ok || reject.RejectReason != "MARGIN_RATE_INVALID" {
+		t.Fatalf("Transaction() should return the *oanda.ClientConfigureRejectTransaction but returned: %+v", tx)
+	}
+}
@@ -0,0 +1,183 @@
+package oanda
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"time"
+)
+
+/*
+struct for unmarshalling a bucket of an order book or position book, the
+percentage of all orders or positions in the book within BucketWidth of Price
+*/
+type BookBucket struct {
+	Price             string `json:"price"`
+	LongCountPercent  string `json:"longCountPercent"`
+	ShortCountPercent string `json:"shortCountPercent"`
+}
+
+/*
+struct for unmarshalling an order book or position book from
+[Instrument Endpoints], a snapshot of the orders or positions held by
+Oanda's clients bucketed by price
+
+endpoint: /v3/instruments/{instrument}/orderBook
+
+endpoint: /v3/instruments/{instrument}/positionBook
+
+[Instrument Endpoints]: https://developer.oanda.com/rest-live-v20/instrument-ep/
+*/
+type Book struct {
+	Instrument  string       `json:"instrument"`
+	Time        Time         `json:"time"`
+	Price       string       `json:"price"`
+	BucketWidth string       `json:"bucketWidth"`
+	Buckets     []BookBucket `json:"buckets"`
+}
+
+// bookQuery returns the query parameters for a book at t, or the latest
+// book when t is zero.
+func bookQuery(t time.Time, format DatetimeFormat) url.Values {
+	q := url.Values{}
+	if !t.IsZero() {
+		q.Add("time", format.format(t))
+	}
+	return q
+}
+
+/*
+GetOrderBook method will return the order book for an instrument at t,
+or the latest order book when t is zero.
+
+endpoint: /v3/instruments/{instrument}/orderBook
+*/
+func (c *Client) GetOrderBook(ctx context.Context, instrument string, t time.Time) (*Book, error) {
+	var response struct {
+		OrderBook Book `json:"orderBook"`
+	}
+	if err := c.get(ctx, "/v3/instruments/"+url.PathEscape(instrument)+"/orderBook", bookQuery(t, c.datetimeFormat), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.OrderBook, nil
+}
+
+/*
+GetPositionBook method will return the position book for an instrument at t,
+or the latest position book when t is zero.
+
+endpoint: /v3/instruments/{instrument}/positionBook
+*/
+func (c *Client) GetPositionBook(ctx context.Context, instrument string, t time.Time) (*Book, error) {
+	var response struct {
+		PositionBook Book `json:"positionBook"`
+	}
+	if err := c.get(ctx, "/v3/instruments/"+url.PathEscape(instrument)+"/positionBook", bookQuery(t, c.datetimeFormat), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.PositionBook, nil
+}
+
+// bucketValues parses the price and percentages of a bucket.
+func bucketValues(b BookBucket) (price, long, short Decimal, err error) {
+	var p decimalParser
+	price, long, short = p.parse(b.Price), p.parse(b.LongCountPercent), p.parse(b.ShortCountPercent)
+	return price, long, short, p.err
+}
+
+/*
+NearestBucket method returns the bucket with the price closest to price,
+false is returned when the book has no buckets. Buckets which cannot be
+parsed are skipped.
+*/
+func (b *Book) NearestBucket(price Decimal) (BookBucket, bool) {
+	var nearest BookBucket
+	var best Decimal
+	found := false
+	for _, bucket := range b.Buckets {
+		p, _, _, err := bucketValues(bucket)
+		if err != nil {
+			continue
+		}
+		if d := p.Sub(price).Abs(); !found || d.Cmp(best) < 0 {
+			nearest, best, found = bucket, d, true
+		}
+	}
+	return nearest, found
+}
+
+/*
+Aggregate method combines the buckets into wider price bands of width,
+summing their percentages. Each band's Price is the lowest price it
+covers, bands are sorted by price and empty bands are left out.
+
+Buckets which cannot be parsed are skipped and a width of zero or less
+returns nil.
+*/
+func (b *Book) Aggregate(width Decimal) []BookBucket {
+	if width.Sign() <= 0 {
+		return nil
+	}
+
+	type band struct{ long, short Decimal }
+	bands := make(map[int64]*band)
+	for _, bucket := range b.Buckets {
+		p, long, short, err := bucketValues(bucket)
+		if err != nil {
+			continue
+		}
+		key := p.floorDiv(width).Int64()
+		if bands[key] == nil {
+			bands[key] = &band{}
+		}
+		bands[key].long = bands[key].long.Add(long)
+		bands[key].short = bands[key].short.Add(short)
+	}
+
+	keys := make([]int64, 0, len(bands))
+	for key := range bands {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	aggregated := make([]BookBucket, 0, len(keys))
+	for _, key := range keys {
+		aggregated = append(aggregated, BookBucket{
+			Price:             NewDecimal(key, 0).Mul(width).String(),
+			LongCountPercent:  bands[key].long.String(),
+			ShortCountPercent: bands[key].short.String(),
+		})
+	}
+	return aggregated
+}
+
+/*
+BookSentiment is the share of an order book or position book which is long
+and short, in percent. Net is Long minus Short, positive when the book is
+net long.
+*/
+type BookSentiment struct {
+	Long  Decimal
+	Short Decimal
+	Net   Decimal
+}
+
+/*
+Sentiment method sums the long and short percentages of every bucket.
+Buckets which cannot be parsed are skipped.
+*/
+func (b *Book) Sentiment() BookSentiment {
+	var s BookSentiment
+	for _, bucket := range b.Buckets {
+		_, long, short, err := bucketValues(bucket)
+		if err != nil {
+			continue
+		}
+		s.Long = s.Long.Add(long)
+		s.Short = s.Short.Add(short)
+	}
+	s.Net = s.Long.Sub(s.Short)
+	return s
+}
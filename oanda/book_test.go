@@ -0,0 +1,90 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestGetOrderBook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/instruments/EUR_USD/orderBook" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if want := "time=2024-01-02T10%3A00%3A00Z"; r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"orderBook":{"instrument":"EUR_USD","time":"2024-01-02T10:00:00Z","price":"1.0850","bucketWidth":"0.0005","buckets":[
+	{"price":"1.0840","longCountPercent":"0.5","shortCountPercent":"0.1"},
+	{"price":"1.0845","longCountPercent":"0.3","shortCountPercent":"0.2"}
+]}}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	book, err := client.GetOrderBook(context.Background(), "EUR_USD", time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetOrderBook() produced an error: %v", err)
+	}
+	if book.BucketWidth != "0.0005" || len(book.Buckets) != 2 || book.Buckets[1].ShortCountPercent != "0.2" {
+		t.Errorf("GetOrderBook() did not decode every field: %+v", book)
+	}
+}
+
+func TestGetPositionBookEscapesInstrument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v3/instruments/EUR%2FUSD/positionBook"; r.URL.EscapedPath() != want {
+			t.Errorf("path should be %s but is: %s", want, r.URL.EscapedPath())
+		}
+		fmt.Fprint(w, `{"positionBook":{"instrument":"EUR_USD","buckets":[]}}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	if _, err := client.GetPositionBook(context.Background(), "EUR/USD", time.Time{}); err != nil {
+		t.Fatalf("GetPositionBook() produced an error: %v", err)
+	}
+}
+
+func TestBookHelpers(t *testing.T) {
+	book := &oanda.Book{
+		BucketWidth: "0.0005",
+		Buckets: []oanda.BookBucket{
+			{Price: "1.0840", LongCountPercent: "0.5", ShortCountPercent: "0.1"},
+			{Price: "1.0845", LongCountPercent: "0.3", ShortCountPercent: "0.2"},
+			{Price: "1.0850", LongCountPercent: "0.1", ShortCountPercent: "0.6"},
+			{Price: "1.0855", LongCountPercent: "0.2", ShortCountPercent: "0.4"},
+		},
+	}
+
+	bucket, ok := book.NearestBucket(oanda.MustParseDecimal("1.08461"))
+	if !ok || bucket.Price != "1.0845" {
+		t.Errorf("NearestBucket() should return the 1.0845 bucket but returned: %+v", bucket)
+	}
+	if _, ok := (&oanda.Book{}).NearestBucket(oanda.MustParseDecimal("1.0845")); ok {
+		t.Error("NearestBucket() should return false for an empty book")
+	}
+
+	bands := book.Aggregate(oanda.MustParseDecimal("0.001"))
+	if len(bands) != 2 {
+		t.Fatalf("Aggregate() should return 2 bands but returned: %+v", bands)
+	}
+	if bands[0].Price != "1.084" || bands[0].LongCountPercent != "0.8" {
+		t.Errorf("first band should start at 1.084 with 0.8%% long but is: %+v", bands[0])
+	}
+	if bands[1].Price != "1.085" || bands[1].ShortCountPercent != "1.0" {
+		t.Errorf("second band should start at 1.085 with 1%% short but is: %+v", bands[1])
+	}
+
+	sentiment := book.Sentiment()
+	if sentiment.Long.String() != "1.1" || sentiment.Short.String() != "1.3" || sentiment.Net.String() != "-0.2" {
+		t.Errorf("Sentiment() should be 1.1 long, 1.3 short but is: %+v", sentiment)
+	}
+}
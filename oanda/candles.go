@@ -0,0 +1,155 @@
+package oanda
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCandleCount is the most candles Oanda will return for one request.
+const maxCandleCount = 5000
+
+// weekdays accepted by the weeklyAlignment query parameter
+var weekdays = map[string]bool{
+	"Monday":    true,
+	"Tuesday":   true,
+	"Wednesday": true,
+	"Thursday":  true,
+	"Friday":    true,
+	"Saturday":  true,
+	"Sunday":    true,
+}
+
+// CandlesRequest holds the query parameters for Oanda's [Instrument - candles endpoint].
+// Zero values are not sent, so Oanda's defaults are used instead.
+//
+// [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
+type CandlesRequest struct {
+	// Price components to get candlestick data for, any combination
+	// of "M" (midpoint), "B" (bid) and "A" (ask). Defaults to "M".
+	Price string
+	// Granularity of the candlesticks, i.e. GranularityS5 for 5 second candles.
+	// Defaults to GranularityS5.
+	Granularity Granularity
+	// Count is the number of candlesticks to return, at most 5000.
+	// Defaults to 500 unless both From and To are set.
+	Count int
+	// From is the start of the time range to fetch candlesticks for.
+	From time.Time
+	// To is the end of the time range to fetch candlesticks for.
+	To time.Time
+	// Smooth uses the previous candle's close price as its open price.
+	Smooth bool
+	// IncludeFirst sets whether the candlestick covered by From should be
+	// returned, it may only be set with From. Defaults to true.
+	IncludeFirst *bool
+	// DailyAlignment is the hour of day (0 to 23) used for granularities
+	// with daily alignment. Defaults to 17.
+	DailyAlignment *int
+	// AlignmentTimezone is the timezone DailyAlignment is in,
+	// i.e. "America/New_York" which is the default.
+	AlignmentTimezone string
+	// WeeklyAlignment is the day of the week used for granularities
+	// with weekly alignment, i.e. "Friday" which is the default.
+	WeeklyAlignment string
+}
+
+// Validate checks for parameters or combinations of parameters
+// which Oanda would reject.
+func (r *CandlesRequest) Validate() error {
+	seen := make(map[rune]bool)
+	for _, p := range r.Price {
+		if !strings.ContainsRune("MBA", p) {
+			return fmt.Errorf("invalid candles request: price component %q must be one of M, B or A", p)
+		}
+		if seen[p] {
+			return fmt.Errorf("invalid candles request: price component %q is repeated", p)
+		}
+		seen[p] = true
+	}
+
+	if r.Granularity != "" {
+		if err := r.Granularity.Validate(); err != nil {
+			return fmt.Errorf("invalid candles request: %w", err)
+		}
+	}
+	if r.Count < 0 || r.Count > maxCandleCount {
+		return fmt.Errorf("invalid candles request: count must be between 0 (unset) and %d but is %d", maxCandleCount, r.Count)
+	}
+	if r.Count > 0 && !r.From.IsZero() && !r.To.IsZero() {
+		return fmt.Errorf("invalid candles request: count cannot be set with both from and to")
+	}
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return fmt.Errorf("invalid candles request: to (%s) is before from (%s)", r.To, r.From)
+	}
+	if r.IncludeFirst != nil && r.From.IsZero() {
+		return fmt.Errorf("invalid candles request: includeFirst can only be set with from")
+	}
+	if r.DailyAlignment != nil && (*r.DailyAlignment < 0 || *r.DailyAlignment > 23) {
+		return fmt.Errorf("invalid candles request: dailyAlignment must be between 0 and 23 but is %d", *r.DailyAlignment)
+	}
+	if r.WeeklyAlignment != "" && !weekdays[r.WeeklyAlignment] {
+		return fmt.Errorf("invalid candles request: weeklyAlignment %q is not a day of the week", r.WeeklyAlignment)
+	}
+
+	return nil
+}
+
+// query returns the request as url query parameters, with times in format.
+func (r *CandlesRequest) query(format DatetimeFormat) url.Values {
+	q := url.Values{}
+	if r.Price != "" {
+		q.Add("price", r.Price)
+	}
+	if r.Granularity != "" {
+		q.Add("granularity", string(r.Granularity))
+	}
+	if r.Count > 0 {
+		q.Add("count", strconv.Itoa(r.Count))
+	}
+	if !r.From.IsZero() {
+		q.Add("from", format.format(r.From))
+	}
+	if !r.To.IsZero() {
+		q.Add("to", format.format(r.To))
+	}
+	if r.Smooth {
+		q.Add("smooth", "true")
+	}
+	if r.IncludeFirst != nil {
+		q.Add("includeFirst", strconv.FormatBool(*r.IncludeFirst))
+	}
+	if r.DailyAlignment != nil {
+		q.Add("dailyAlignment", strconv.Itoa(*r.DailyAlignment))
+	}
+	if r.AlignmentTimezone != "" {
+		q.Add("alignmentTimezone", r.AlignmentTimezone)
+	}
+	if r.WeeklyAlignment != "" {
+		q.Add("weeklyAlignment", r.WeeklyAlignment)
+	}
+	return q
+}
+
+// GetCandles method fetches candlestick data for an instrument. The request
+// is validated before it is sent, a nil request uses Oanda's defaults.
+//
+// endpoint: /v3/instruments/{instrument}/candles
+func (c *Client) GetCandles(ctx context.Context, instrument string, req *CandlesRequest) (*Metadata, error) {
+	if req == nil {
+		req = &CandlesRequest{}
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var candles Metadata
+	if err := c.get(ctx, "/v3/instruments/"+url.PathEscape(instrument)+"/candles", req.query(c.datetimeFormat), &candles); err != nil {
+		return nil, err
+	}
+
+	return &candles, nil
+}
@@ -0,0 +1,91 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestCandlesRequestValidate(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	includeFirst := false
+	badAlignment := 24
+
+	invalid := map[string]oanda.CandlesRequest{
+		"unknown price":          {Price: "BX"},
+		"repeated price":         {Price: "MM"},
+		"count too large":        {Count: 5001},
+		"count with from and to": {Count: 10, From: from, To: to},
+		"to before from":         {From: to, To: from},
+		"includeFirst w/o from":  {IncludeFirst: &includeFirst},
+		"dailyAlignment":         {DailyAlignment: &badAlignment},
+		"weeklyAlignment":        {WeeklyAlignment: "Funday"},
+		"granularity":            {Granularity: "S6"},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: Validate() should fail for %+v", name, req)
+		}
+	}
+
+	valid := []oanda.CandlesRequest{
+		{},
+		{Price: "MBA", Granularity: "M1", Count: 5000},
+		{From: from, To: to, IncludeFirst: &includeFirst},
+		{From: from, Count: 100, WeeklyAlignment: "Monday"},
+	}
+	for _, req := range valid {
+		if err := req.Validate(); err != nil {
+			t.Errorf("Validate() should pass for %+v but produced an error: %v", req, err)
+		}
+	}
+}
+
+func TestGetCandles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "count=2&from=2024-07-01T00%3A00%3A00Z&granularity=M1&price=MB"
+		if r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"instrument":"EUR_USD","granularity":"M1","candles":[
+	{"complete":true,"volume":5,"time":"2024-07-01T00:00:00.000000000Z","mid":{"o":"1.07","h":"1.08","l":"1.06","c":"1.075"},"bid":{"o":"1.06","h":"1.07","l":"1.05","c":"1.065"}},
+	{"complete":false,"volume":2,"time":"2024-07-01T00:01:00.000000000Z","mid":{"o":"1.075","h":"1.08","l":"1.07","c":"1.08"},"bid":{"o":"1.065","h":"1.07","l":"1.06","c":"1.07"}}
+]}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+	data, err := client.GetCandles(context.Background(), "EUR_USD", &oanda.CandlesRequest{
+		Price:       "MB",
+		Granularity: "M1",
+		Count:       2,
+		From:        time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetCandles() produced an error: %v", err)
+	}
+	if len(data.Candles) != 2 || data.Candles[0].Mid.C != "1.075" {
+		t.Fatalf("GetCandles() did not unmarshal mid prices: %+v", data.Candles)
+	}
+}
+
+func TestGetCandlesEscapesInstrument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v3/instruments/EUR%2FUSD/candles"; r.URL.EscapedPath() != want {
+			t.Errorf("path should be %s but is: %s", want, r.URL.EscapedPath())
+		}
+		fmt.Fprint(w, `{"instrument":"EUR_USD","granularity":"S5","candles":[]}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+	if _, err := client.GetCandles(context.Background(), "EUR/USD", nil); err != nil {
+		t.Fatalf("GetCandles() produced an error: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package oanda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// default hosts for Oanda's fxTrade Practice environment
+const (
+	practiceURL       = "https://api-fxpractice.oanda.com"
+	practiceStreamURL = "https://stream-fxpractice.oanda.com"
+)
+
+// defaultTimeout is used for the http.Client created by NewClient when
+// one is not provided with WithHTTPClient.
+const defaultTimeout = time.Second * 10
+
+// Client is used for querying Oanda's REST-V20 API. A single Client
+// should be reused between requests so that the underlying http.Client
+// can share its connection pool.
+//
+// Create one with NewClient, for example:
+//
+//	client := oanda.NewClient(
+//		oanda.WithToken(token),
+//		oanda.WithAccountID(id),
+//	)
+type Client struct {
+	env         Environment
+	liveTrading bool
+	baseURL     string
+	streamURL   string
+	token       string
+	accountID   string
+	userAgent   string
+	httpClient  *http.Client
+
+	datetimeFormat DatetimeFormat
+	// streamURLSet is whether WithStreamURL was used, a Custom environment
+	// must not fall back to Oanda's stream host.
+	streamURLSet bool
+}
+
+// Option configures a Client, see NewClient.
+type Option func(*Client)
+
+// WithBaseURL sets the host used for REST requests,
+// i.e. "https://api-fxpractice.oanda.com", and the environment to Custom.
+// Streams fail with ErrStreamURLUnset unless WithStreamURL is also used.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.env = Custom
+		c.baseURL = baseURL
+	}
+}
+
+// WithStreamURL sets the host used for streaming requests,
+// i.e. "https://stream-fxpractice.oanda.com", and the environment to Custom.
+func WithStreamURL(streamURL string) Option {
+	return func(c *Client) {
+		c.env = Custom
+		c.streamURL = streamURL
+		c.streamURLSet = true
+	}
+}
+
+// WithToken sets the bearer token sent with every request.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAccountID sets the account used by account scoped endpoints.
+func WithAccountID(id string) Option {
+	return func(c *Client) {
+		c.accountID = id
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests. Use this to
+// swap the transport or change the default 10 second timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// NewClient returns a Client configured with the given options. Requests
+// are sent to the Practice environment unless WithEnvironment is used.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		env:       Practice,
+		baseURL:   practiceURL,
+		streamURL: practiceStreamURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{
+			Timeout: defaultTimeout,
+		}
+	}
+	return c
+}
+
+// AccountID returns the account used by account scoped endpoints.
+func (c *Client) AccountID() string {
+	return c.accountID
+}
+
+// newRequest prepares a request for the REST host with headers set
+// as recommended by Oanda's [Best Practices]. The request is cancelled
+// when ctx is done.
+//
+// [Best Practices]: https://developer.oanda.com/rest-live-v20/best-practices/
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	return c.newHostRequest(ctx, c.baseURL, method, path, query, body)
+}
+
+// newHostRequest is the same as newRequest but for the given host, use
+// it with c.streamURL for streaming endpoints.
+func (c *Client) newHostRequest(ctx context.Context, host, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, host+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("error: %s", err.Error())
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+c.token)
+	req.Header.Add("Accept-Datetime-Format", c.datetimeFormat.String())
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	return req, nil
+}
+
+// do sends the request and unmarshals the json response into v. Responses
+// with a status code of 400 or greater are returned as an *APIError.
+func (c *Client) do(req *http.Request, v any) error {
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		// wrap error so callers can check for context.Canceled
+		// and context.DeadlineExceeded with errors.Is
+		return fmt.Errorf("error: %w", err)
+	}
+	defer response.Body.Close()
+
+	// response body is []byte
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %s", err.Error())
+	}
+
+	if response.StatusCode >= 400 {
+		return newAPIError(response, body)
+	}
+
+	// some endpoints have nothing worth returning
+	if v == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error unmarshaling json: %s", err.Error())
+	}
+
+	return nil
+}
+
+// get sends a GET request for path on the REST host and unmarshals
+// the json response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	req, err := c.newRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, v)
+}
+
+// send marshals body as json and sends it with method (i.e. "POST" or "PUT")
+// for path on the REST host, then unmarshals the json response into v.
+func (c *Client) send(ctx context.Context, method, path string, body any, v any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %s", err.Error())
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := c.newRequest(ctx, method, path, nil, reader)
+	if err != nil {
+		return err
+	}
+	return c.do(req, v)
+}
+
+// accountPath returns the path for an endpoint scoped to the client's account.
+func (c *Client) accountPath(endpoint string) string {
+	return "/v3/accounts/" + c.accountID + endpoint
+}
@@ -0,0 +1,97 @@
+package oanda_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestClientRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/summary" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization header should be 'Bearer secret' but is: %s", got)
+		}
+		if got := r.Header.Get("User-Agent"); got != "oanda-go-test" {
+			t.Errorf("User-Agent header should be 'oanda-go-test' but is: %s", got)
+		}
+		fmt.Fprint(w, `{"account":{"currency":"CAD"},"lastTransactionID":"6"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithToken("secret"),
+		oanda.WithAccountID("101-001-1234567-001"),
+		oanda.WithHTTPClient(server.Client()),
+		oanda.WithUserAgent("oanda-go-test"),
+	)
+
+	summary, err := client.GetAccountSummary()
+	if err != nil {
+		t.Fatalf("GetAccountSummary() produced an error: %v", err)
+	}
+	if summary.Account.Currency != "CAD" || summary.LastTransactionID != "6" {
+		t.Fatalf("GetAccountSummary() did not unmarshal response: %+v", summary)
+	}
+}
+
+func TestClientErrorMsg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errorMessage":"Invalid value specified for 'sinceTransactionID'"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	_, err := client.GetAccountChanges("abc")
+	var errorMsg *oanda.ErrorMsg
+	if !errors.As(err, &errorMsg) {
+		t.Fatalf("GetAccountChanges() should return *oanda.ErrorMsg but returned: %v", err)
+	}
+	if errorMsg.Empty() {
+		t.Fatal("ErrorMsg returned by GetAccountChanges() should not be empty")
+	}
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// block until the client gives up on the request
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetCandlesBAContext(ctx, "USD_CAD", "S5", false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetCandlesBAContext() should fail with context.DeadlineExceeded but returned: %v", err)
+	}
+}
+
+func TestGetCandlesBAGranularityString(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent but got: %s", r.URL)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	// callers pass granularity as a plain string variable
+	granularity := "S6"
+	if _, err := client.GetCandlesBA("USD_CAD", granularity, false); err == nil {
+		t.Fatal("GetCandlesBA() should fail for an invalid granularity")
+	}
+}
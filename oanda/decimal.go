@@ -0,0 +1,476 @@
+package oanda
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+Decimal is an exact fixed-point decimal number, used for the prices, units
+and amounts of money which Oanda sends as quoted decimal strings, i.e. "1.08512".
+Unlike float64 it never rounds unless asked to, so sums of money are exact.
+
+The zero value is 0. Decimals are immutable, every method returns a new
+Decimal. Compare them with Cmp or Equal, not ==.
+
+	price, err := oanda.ParseDecimal(candle.Bid.C)
+	if err != nil {
+		return err
+	}
+	cost := price.Mul(oanda.NewDecimal(1000, 0)).Round(2)
+*/
+type Decimal struct {
+	// value is coef * 10^-scale, a nil coef is zero
+	coef  *big.Int
+	scale int
+}
+
+// ten is used to shift coefficients, it must never be modified.
+var ten = big.NewInt(10)
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// NewDecimal returns value * 10^-scale, i.e. NewDecimal(12345, 4) is 1.2345.
+func NewDecimal(value int64, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(value), scale: scale}
+}
+
+// ParseDecimal parses a decimal string such as "1.08512", "-100" or ".5".
+func ParseDecimal(s string) (Decimal, error) {
+	digits := s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" || strings.Trim(whole+frac, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("error parsing decimal: %q is not a decimal number", s)
+	}
+
+	coef, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("error parsing decimal: %q is not a decimal number", s)
+	}
+	if strings.HasPrefix(s, "-") {
+		coef.Neg(coef)
+	}
+	return Decimal{coef: coef, scale: len(frac)}, nil
+}
+
+// MustParseDecimal is the same as ParseDecimal but panics if s cannot be
+// parsed, use it for constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// int returns the coefficient, treating nil as zero.
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a larger scale.
+func (d Decimal) rescale(scale int) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// align returns the coefficients of d and e at the same scale.
+func align(d, e Decimal) (a, b *big.Int, scale int) {
+	scale = max(d.scale, e.scale)
+	return d.rescale(scale), e.rescale(scale), scale
+}
+
+// floorDiv returns d / e rounded down to a whole number, e must be positive.
+func (d Decimal) floorDiv(e Decimal) *big.Int {
+	a, b, _ := align(d, e)
+	// Div is Euclidean division, which rounds down for a positive divisor
+	return new(big.Int).Div(a, b)
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Mul returns d * e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Div returns d / e rounded half away from zero to places decimal places.
+// Like integer division it panics if e is zero.
+func (d Decimal) Div(e Decimal, places int) Decimal {
+	if e.IsZero() {
+		panic("oanda: division of decimal by zero")
+	}
+	// d/e = (a * 10^-d.scale) / (b * 10^-e.scale), computed with one extra
+	// digit so the result can be rounded
+	num := new(big.Int).Mul(d.int(), pow10(max(0, places+1+e.scale-d.scale)))
+	den := new(big.Int).Mul(e.int(), pow10(max(0, d.scale-e.scale-places-1)))
+	return Decimal{coef: new(big.Int).Quo(num, den), scale: places + 1}.Round(places)
+}
+
+// Shift returns d * 10^n, i.e. Shift(4) turns a EUR_USD price difference
+// into pips. It is exact for any n.
+func (d Decimal) Shift(n int) Decimal {
+	if n <= d.scale {
+		return Decimal{coef: d.int(), scale: d.scale - n}
+	}
+	return Decimal{coef: new(big.Int).Mul(d.int(), pow10(n-d.scale))}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Sign returns -1, 0 or +1 when d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or +1 when d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b, _ := align(d, e)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and e are the same number, "1.10" equals "1.1".
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Round returns d rounded half away from zero to places decimal places,
+// use an instrument's DisplayPrecision to round a price.
+func (d Decimal) Round(places int) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return d
+	}
+
+	q, r := new(big.Int).QuoRem(d.int(), pow10(d.scale-places), new(big.Int))
+	// round away from zero when the remainder is at least half
+	half := new(big.Int).Mul(big.NewInt(5), pow10(d.scale-places-1))
+	if r.CmpAbs(half) >= 0 {
+		q.Add(q, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{coef: q, scale: places}
+}
+
+// Truncate returns d with every digit after places decimal places dropped.
+func (d Decimal) Truncate(places int) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return d
+	}
+	return Decimal{coef: new(big.Int).Quo(d.int(), pow10(d.scale-places)), scale: places}
+}
+
+// Float64 returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d with exactly Scale digits after the decimal point.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes d as a quoted decimal string, the same as Oanda.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a quoted decimal string or a json number,
+// null and "" decode as zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// decimalParser parses several decimal strings, keeping the first error.
+// Empty strings are fields Oanda left out and parse as zero.
+type decimalParser struct {
+	err error
+}
+
+func (p *decimalParser) parse(s string) Decimal {
+	if s == "" || p.err != nil {
+		return Decimal{}
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		p.err = err
+	}
+	return d
+}
+
+/*
+CandlePrices holds the open, high, low and close of a candle as Decimals,
+see Bid.Decimals, Ask.Decimals and Mid.Decimals.
+*/
+type CandlePrices struct {
+	O, H, L, C Decimal
+}
+
+func candlePrices(o, h, l, c string) (CandlePrices, error) {
+	var p decimalParser
+	prices := CandlePrices{O: p.parse(o), H: p.parse(h), L: p.parse(l), C: p.parse(c)}
+	return prices, p.err
+}
+
+// Decimals returns the bid prices of the candle as Decimals.
+func (b Bid) Decimals() (CandlePrices, error) {
+	return candlePrices(b.O, b.H, b.L, b.C)
+}
+
+// Decimals returns the ask prices of the candle as Decimals.
+func (a Ask) Decimals() (CandlePrices, error) {
+	return candlePrices(a.O, a.H, a.L, a.C)
+}
+
+// Decimals returns the mid prices of the candle as Decimals.
+func (m Mid) Decimals() (CandlePrices, error) {
+	return candlePrices(m.O, m.H, m.L, m.C)
+}
+
+// Decimal returns the bucket's price as a Decimal.
+func (b PriceBucket) Decimal() (Decimal, error) {
+	return ParseDecimal(b.Price)
+}
+
+// BestBid returns the highest bid as a Decimal.
+func (s ClientPrice) BestBid() (Decimal, error) {
+	if len(s.Bids) == 0 {
+		return Decimal{}, fmt.Errorf("error: %s price has no bids", s.Instrument)
+	}
+	return s.Bids[0].Decimal()
+}
+
+// Closeout returns the closeout bid and ask, the prices an open position
+// would be closed at, as Decimals.
+func (s ClientPrice) Closeout() (bid, ask Decimal, err error) {
+	var p decimalParser
+	bid, ask = p.parse(s.CloseOutBid), p.parse(s.CloseOutAsk)
+	return bid, ask, p.err
+}
+
+// BestAsk returns the lowest ask as a Decimal.
+func (s ClientPrice) BestAsk() (Decimal, error) {
+	if len(s.Asks) == 0 {
+		return Decimal{}, fmt.Errorf("error: %s price has no asks", s.Instrument)
+	}
+	return s.Asks[0].Decimal()
+}
+
+// parseDecimalFields sets every Decimal field of dst, a pointer to a struct,
+// to the parsed value of the string field with the same name in src, a
+// struct or a pointer to one. Fields src does not have are left zero.
+func parseDecimalFields(dst, src any) error {
+	from := reflect.Indirect(reflect.ValueOf(src))
+	to := reflect.ValueOf(dst).Elem()
+
+	var p decimalParser
+	for i := 0; i < to.NumField(); i++ {
+		field := from.FieldByName(to.Type().Field(i).Name)
+		if field.IsValid() && field.Kind() == reflect.String {
+			to.Field(i).Set(reflect.ValueOf(p.parse(field.String())))
+		}
+	}
+	return p.err
+}
+
+/*
+AccountBalances holds the balance, profit and loss and margin of an account
+as Decimals, see IdDetails.Balances, SummaryDetails.Balances and
+State.Balances. Fields which were not sent are zero.
+*/
+type AccountBalances struct {
+	Balance                     Decimal
+	NAV                         Decimal
+	PL                          Decimal
+	ResettablePL                Decimal
+	UnrealizedPL                Decimal
+	Financing                   Decimal
+	Commission                  Decimal
+	DividendAdjustment          Decimal
+	GuaranteedExecutionFees     Decimal
+	MarginUsed                  Decimal
+	MarginAvailable             Decimal
+	PositionValue               Decimal
+	MarginCloseoutUnrealizedPL  Decimal
+	MarginCloseoutNAV           Decimal
+	MarginCloseoutMarginUsed    Decimal
+	MarginCloseoutPercent       Decimal
+	MarginCloseoutPositionValue Decimal
+	MarginCallMarginUsed        Decimal
+	MarginCallPercent           Decimal
+	WithdrawalLimit             Decimal
+}
+
+// Balances returns the account's balances as Decimals.
+func (d *IdDetails) Balances() (AccountBalances, error) {
+	var balances AccountBalances
+	return balances, parseDecimalFields(&balances, d)
+}
+
+// Balances returns the account's balances as Decimals.
+func (d *SummaryDetails) Balances() (AccountBalances, error) {
+	var balances AccountBalances
+	return balances, parseDecimalFields(&balances, d)
+}
+
+// Balances returns the account's balances as Decimals.
+func (s *State) Balances() (AccountBalances, error) {
+	var balances AccountBalances
+	return balances, parseDecimalFields(&balances, s)
+}
+
+/*
+PositionAmounts holds the profit and loss and margin of a position as
+Decimals, see PositionsID.Decimals. Fields which were not sent are zero.
+*/
+type PositionAmounts struct {
+	PL                      Decimal
+	UnrealizedPL            Decimal
+	MarginUsed              Decimal
+	ResettablePL            Decimal
+	Financing               Decimal
+	Commission              Decimal
+	DividendAdjustment      Decimal
+	GuaranteedExecutionFees Decimal
+}
+
+// Decimals returns the position's amounts as Decimals.
+func (p PositionsID) Decimals() (PositionAmounts, error) {
+	var amounts PositionAmounts
+	return amounts, parseDecimalFields(&amounts, p)
+}
+
+/*
+PositionSideAmounts holds the units, average price, profit and loss and
+margin of the long or short side of a position as Decimals, see
+Long.Decimals and Short.Decimals. Fields which were not sent are zero.
+*/
+type PositionSideAmounts struct {
+	Units                   Decimal
+	AveragePrice            Decimal
+	PL                      Decimal
+	ResettablePL            Decimal
+	Financing               Decimal
+	DividendAdjustment      Decimal
+	GuaranteedExecutionFees Decimal
+	UnrealizedPL            Decimal
+	MarginUsed              Decimal
+}
+
+// Decimals returns the long side's amounts as Decimals.
+func (l Long) Decimals() (PositionSideAmounts, error) {
+	var amounts PositionSideAmounts
+	return amounts, parseDecimalFields(&amounts, l)
+}
+
+// Decimals returns the short side's amounts as Decimals, Units is negative.
+func (s Short) Decimals() (PositionSideAmounts, error) {
+	var amounts PositionSideAmounts
+	return amounts, parseDecimalFields(&amounts, s)
+}
+
+/*
+TradeAmounts holds the units, prices, profit and loss and margin of a trade
+as Decimals, see TradeBase.Decimals. Fields which were not sent are zero.
+*/
+type TradeAmounts struct {
+	Price                 Decimal
+	InitialUnits          Decimal
+	InitialMarginRequired Decimal
+	CurrentUnits          Decimal
+	RealizedPL            Decimal
+	UnrealizedPL          Decimal
+	MarginUsed            Decimal
+	AverageClosePrice     Decimal
+	Financing             Decimal
+	DividendAdjustment    Decimal
+}
+
+// Decimals returns the trade's amounts as Decimals.
+func (t TradeBase) Decimals() (TradeAmounts, error) {
+	var amounts TradeAmounts
+	return amounts, parseDecimalFields(&amounts, t)
+}
@@ -0,0 +1,167 @@
+package oanda_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1.08512", "1.08512"},
+		{"-100", "-100"},
+		{".5", "0.5"},
+		{"-0.0005", "-0.0005"},
+		{"+2.50", "2.50"},
+	}
+	for _, tt := range tests {
+		d, err := oanda.ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) produced an error: %v", tt.in, err)
+			continue
+		}
+		if d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) should be %s but is: %s", tt.in, tt.want, d)
+		}
+	}
+
+	for _, in := range []string{"", "-", ".", "1.2.3", "1e5", "abc", "1,000"} {
+		if _, err := oanda.ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) should return an error", in)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	d := oanda.MustParseDecimal
+	tests := []struct {
+		name string
+		got  oanda.Decimal
+		want string
+	}{
+		{"add", d("0.1").Add(d("0.2")), "0.3"},
+		{"sub", d("1.08512").Sub(d("1.085")), "0.00012"},
+		{"mul", d("1.08512").Mul(d("-1000")), "-1085.12000"},
+		{"div", d("10").Div(d("3"), 4), "3.3333"},
+		{"div rounds", d("2").Div(d("3"), 2), "0.67"},
+		{"div small", d("0.0001").Div(d("0.00008"), 3), "1.250"},
+		{"shift", d("0.00123").Shift(4), "12.3"},
+		{"shift left", d("12").Shift(-3), "0.012"},
+		{"round half up", d("1.085125").Round(5), "1.08513"},
+		{"round negative", d("-1.085125").Round(5), "-1.08513"},
+		{"round down", d("1.0851249").Round(5), "1.08512"},
+		{"round to whole", d("0.5").Round(0), "1"},
+		{"truncate", d("-1.0859").Truncate(3), "-1.085"},
+		{"neg", d("0.5").Neg(), "-0.5"},
+		{"abs", d("-0.5").Abs(), "0.5"},
+		{"zero value", oanda.Decimal{}.Add(d("1")), "1"},
+		{"new", oanda.NewDecimal(12345, 4), "1.2345"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s should be %s but is: %s", tt.name, tt.want, tt.got)
+		}
+	}
+
+	if !d("1.10").Equal(d("1.1")) || d("1.1").Cmp(d("1.09")) != 1 || d("-1").Cmp(d("0")) != -1 {
+		t.Error("Cmp() and Equal() should compare numerically")
+	}
+	if !(oanda.Decimal{}).IsZero() || d("-0.5").Sign() != -1 {
+		t.Error("IsZero() and Sign() are wrong")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Price oanda.Decimal `json:"price"`
+		Units oanda.Decimal `json:"units"`
+		Empty oanda.Decimal `json:"empty"`
+	}
+	if err := json.Unmarshal([]byte(`{"price":"1.08512","units":-100,"empty":null}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal() produced an error: %v", err)
+	}
+	if v.Price.String() != "1.08512" || v.Units.String() != "-100" || !v.Empty.IsZero() {
+		t.Errorf("decimals were not decoded: %+v", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if want := `{"price":"1.08512","units":"-100","empty":"0"}`; string(data) != want {
+		t.Errorf("json.Marshal() should return %s but returned: %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"abc"}`), &v); err == nil {
+		t.Error("json.Unmarshal() should fail for a price which is not a decimal")
+	}
+}
+
+func TestDecimalAccessors(t *testing.T) {
+	prices, err := oanda.Bid{O: "1.0850", H: "1.0860", L: "1.0840", C: "1.0855"}.Decimals()
+	if err != nil {
+		t.Fatalf("Decimals() produced an error: %v", err)
+	}
+	if prices.H.Sub(prices.L).String() != "0.0020" {
+		t.Errorf("candle range should be 0.0020 but is: %s", prices.H.Sub(prices.L))
+	}
+
+	if _, err := (oanda.Mid{O: "x"}).Decimals(); err == nil {
+		t.Error("Decimals() should fail for a price which is not a decimal")
+	}
+
+	details := oanda.IdDetails{Balance: "1000.1000", UnrealizedPL: "-0.2000", NAV: "999.9000"}
+	balances, err := details.Balances()
+	if err != nil {
+		t.Fatalf("Balances() produced an error: %v", err)
+	}
+	if !balances.Balance.Add(balances.UnrealizedPL).Equal(balances.NAV) {
+		t.Errorf("balance plus unrealized PL should equal NAV but balances are: %+v", balances)
+	}
+	if !balances.MarginUsed.IsZero() {
+		t.Errorf("fields which were not sent should be zero but MarginUsed is: %s", balances.MarginUsed)
+	}
+
+	state := oanda.State{MarginCallMarginUsed: "10.5000", MarginCallPercent: "0.01050"}
+	stateBalances, err := state.Balances()
+	if err != nil || stateBalances.MarginCallMarginUsed.String() != "10.5000" || stateBalances.MarginCallPercent.String() != "0.01050" {
+		t.Errorf("margin call fields were not parsed: %+v, %v", stateBalances, err)
+	}
+
+	position := oanda.PositionsID{
+		UnrealizedPL: "1.5000",
+		Long:         oanda.Long{Units: "200", AveragePrice: "1.05000", UnrealizedPL: "1.5000", MarginUsed: "7.0000"},
+		Short:        oanda.Short{Units: "-100", AveragePrice: "1.06000"},
+	}
+	amounts, err := position.Decimals()
+	if err != nil || amounts.UnrealizedPL.String() != "1.5000" {
+		t.Errorf("position amounts were not parsed: %+v, %v", amounts, err)
+	}
+	long, err := position.Long.Decimals()
+	if err != nil || long.Units.String() != "200" || long.AveragePrice.String() != "1.05000" || long.MarginUsed.String() != "7.0000" {
+		t.Errorf("long amounts were not parsed: %+v, %v", long, err)
+	}
+	short, err := position.Short.Decimals()
+	if err != nil || short.Units.Sign() != -1 || short.AveragePrice.String() != "1.06000" {
+		t.Errorf("short amounts were not parsed: %+v, %v", short, err)
+	}
+
+	trade := oanda.TradeSummary{TradeBase: oanda.TradeBase{Price: "1.05000", CurrentUnits: "100", UnrealizedPL: "0.5000"}}
+	tradeAmounts, err := trade.Decimals()
+	if err != nil || tradeAmounts.Price.String() != "1.05000" || tradeAmounts.CurrentUnits.String() != "100" || !tradeAmounts.RealizedPL.IsZero() {
+		t.Errorf("trade amounts were not parsed: %+v, %v", tradeAmounts, err)
+	}
+
+	bid, ask, err := oanda.Stream{CloseOutBid: "1.08500", CloseOutAsk: "1.08520"}.Closeout()
+	if err != nil || ask.Sub(bid).String() != "0.00020" {
+		t.Errorf("closeout prices were not parsed: %s %s, %v", bid, ask, err)
+	}
+
+	if _, err := (oanda.Long{Units: "lots"}).Decimals(); err == nil {
+		t.Error("Decimals() should fail for units which are not a decimal")
+	}
+}
@@ -0,0 +1,160 @@
+package oanda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// default hosts for Oanda's fxTrade (live) environment
+const (
+	liveURL       = "https://api-fxtrade.oanda.com"
+	liveStreamURL = "https://stream-fxtrade.oanda.com"
+)
+
+// Environment selects which of Oanda's hosts a Client sends requests to.
+//
+// In res.json it is set per account with the "environment" field, one of
+// "practice", "live" or "custom". When it is "custom" the "url" and
+// "streamUrl" fields are used as the hosts instead.
+type Environment int
+
+const (
+	// Practice is Oanda's fxTrade Practice (demo) environment, used by default.
+	Practice Environment = iota
+	// Live is Oanda's fxTrade environment, where orders are placed with real money.
+	Live
+	// Custom uses the hosts set with WithBaseURL and WithStreamURL.
+	Custom
+)
+
+// ErrLiveTradingDisabled is returned by any request that would place or
+// modify an order in the Live environment when the client was not
+// created with WithLiveTrading.
+var ErrLiveTradingDisabled = errors.New("live trading is disabled, use WithLiveTrading to enable it")
+
+// ErrStreamURLUnset is returned by streams of a client in the Custom
+// environment which was not given a stream host with WithStreamURL.
+var ErrStreamURLUnset = errors.New("no stream url set for the custom environment, use WithStreamURL to set it")
+
+func (e Environment) String() string {
+	switch e {
+	case Practice:
+		return "practice"
+	case Live:
+		return "live"
+	case Custom:
+		return "custom"
+	default:
+		return fmt.Sprintf("Environment(%d)", int(e))
+	}
+}
+
+// hosts returns the REST and streaming hosts for the environment,
+// both are empty for Custom.
+func (e Environment) hosts() (string, string) {
+	switch e {
+	case Practice:
+		return practiceURL, practiceStreamURL
+	case Live:
+		return liveURL, liveStreamURL
+	default:
+		return "", ""
+	}
+}
+
+// ParseEnvironment returns the Environment for "practice", "live" or "custom".
+func ParseEnvironment(s string) (Environment, error) {
+	switch strings.ToLower(s) {
+	case "", "practice":
+		return Practice, nil
+	case "live":
+		return Live, nil
+	case "custom":
+		return Custom, nil
+	default:
+		return Practice, fmt.Errorf("unknown environment: %q", s)
+	}
+}
+
+func (e *Environment) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	env, err := ParseEnvironment(s)
+	if err != nil {
+		return err
+	}
+	*e = env
+	return nil
+}
+
+func (e Environment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// WithEnvironment sets both the REST and streaming hosts for env.
+// Use WithBaseURL and WithStreamURL for a Custom environment.
+func WithEnvironment(env Environment) Option {
+	return func(c *Client) {
+		c.env = env
+		if env != Custom {
+			c.baseURL, c.streamURL = env.hosts()
+		}
+	}
+}
+
+// WithLiveTrading opts in to sending order placing requests to the
+// Live environment, without it they fail with ErrLiveTradingDisabled.
+func WithLiveTrading() Option {
+	return func(c *Client) {
+		c.liveTrading = true
+	}
+}
+
+// Environment returns the environment the client sends requests to.
+func (c *Client) Environment() Environment {
+	return c.env
+}
+
+// checkTrading must be called before any request which places or modifies
+// an order, it guards against accidentally trading a live account.
+func (c *Client) checkTrading() error {
+	live := c.env == Live || isLiveHost(c.baseURL)
+	if live && !c.liveTrading {
+		return ErrLiveTradingDisabled
+	}
+	return nil
+}
+
+// isLiveHost reports whether baseURL points at the Live environment's host,
+// whatever its scheme, port, path or case.
+func isLiveHost(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	live, _ := url.Parse(liveURL)
+	return strings.EqualFold(u.Hostname(), live.Hostname())
+}
+
+// NewClient returns a Client for the credential's account, token and
+// environment. Any options given are applied afterwards.
+func (cred Credential) NewClient(opts ...Option) *Client {
+	base := []Option{
+		WithEnvironment(cred.Environment),
+		WithToken(cred.Token),
+		WithAccountID(cred.ID),
+	}
+	if cred.BaseURL != "" {
+		base = append(base, WithBaseURL(cred.BaseURL))
+	}
+	if cred.StreamURL != "" {
+		base = append(base, WithStreamURL(cred.StreamURL))
+	}
+	return NewClient(append(base, opts...)...)
+}
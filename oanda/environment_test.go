@@ -0,0 +1,47 @@
+package oanda_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestCredentialEnvironment(t *testing.T) {
+	data := []byte(`{
+	"primary": {"id": "101-001-1234567-001", "token": "abc"},
+	"live": {"id": "001-001-1234567-001", "token": "def", "environment": "live"},
+	"local": {"id": "101-001-1234567-002", "token": "ghi", "environment": "custom", "url": "http://localhost:8080"}
+}`)
+
+	var creds oanda.Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		t.Fatalf("error unmarshaling credentials: %v", err)
+	}
+
+	tests := map[string]oanda.Environment{
+		"primary": oanda.Practice,
+		"live":    oanda.Live,
+		"local":   oanda.Custom,
+	}
+	for name, want := range tests {
+		client := creds.Account[name].NewClient()
+		if got := client.Environment(); got != want {
+			t.Errorf("account %q should use the %v environment but uses: %v", name, want, got)
+		}
+		if got := client.AccountID(); got != creds.Account[name].ID {
+			t.Errorf("account %q should use id %q but uses: %q", name, creds.Account[name].ID, got)
+		}
+	}
+}
+
+func TestParseEnvironmentInvalid(t *testing.T) {
+	if _, err := oanda.ParseEnvironment("fxtrade"); err == nil {
+		t.Fatal("ParseEnvironment() should fail for an unknown environment")
+	}
+
+	var cred oanda.Credential
+	if err := json.Unmarshal([]byte(`{"environment": "demo"}`), &cred); err == nil {
+		t.Fatal("unmarshaling a credential with an unknown environment should fail")
+	}
+}
@@ -0,0 +1,150 @@
+package oanda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors for the HTTP status codes returned by Oanda's REST-V20 API.
+// Every *APIError matches one of these with errors.Is, for example:
+//
+//	if errors.Is(err, oanda.ErrRateLimited) {
+//		// back off before trying again
+//	}
+var (
+	ErrBadRequest       = errors.New("bad request")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrServer           = errors.New("server error")
+)
+
+/*
+APIError is returned by every endpoint when Oanda's REST-V20 API responds with
+a status code of 400 or greater.
+
+It embeds the errorCode and errorMessage returned in the body (see ErrorMsg),
+so errors.As can be used to get either an *APIError or an *ErrorMsg.
+*/
+type APIError struct {
+	ErrorMsg
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// RequestID is the value of the RequestID header Oanda sets on every response.
+	RequestID string
+	// RejectTransaction is the raw json for the transaction which rejected the
+	// request (i.e. "orderRejectTransaction"), empty when there is none. When
+	// the response has several, it is the first in rejectTransactionKeys, or
+	// the first other key in alphabetical order.
+	RejectTransaction json.RawMessage
+	// RejectTransactions holds the raw json of every reject transaction in
+	// the response keyed by its field name, i.e. a replace order error may
+	// have both "orderRejectTransaction" and "orderCancelRejectTransaction".
+	RejectTransactions map[string]json.RawMessage
+	// LastTransactionID is the ID of the most recent transaction created for the account.
+	LastTransactionID string
+	// RelatedTransactionIDs are the IDs of all transactions created while
+	// processing the request.
+	RelatedTransactionIDs []string
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorCode != "" {
+		return fmt.Sprintf("%d error: %s (%s)", e.StatusCode, e.ErrorMessage, e.ErrorCode)
+	}
+	if e.ErrorMessage != "" {
+		return fmt.Sprintf("%d error: %s", e.StatusCode, e.ErrorMessage)
+	}
+	return fmt.Sprintf("%d error: %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether target is the sentinel error for e.StatusCode.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrMethodNotAllowed:
+		return e.StatusCode == http.StatusMethodNotAllowed
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// Unwrap returns the embedded *ErrorMsg.
+func (e *APIError) Unwrap() error {
+	return &e.ErrorMsg
+}
+
+// Transaction returns RejectTransaction decoded into its concrete type,
+// i.e. *MarketOrderRejectTransaction. It returns nil if there is none.
+func (e *APIError) Transaction() (Transaction, error) {
+	return unmarshalOptionalTransaction(e.RejectTransaction)
+}
+
+// rejectTransactionKeys are the reject transactions used for
+// APIError.RejectTransaction in priority order, the rejection of the order
+// being created comes before the rejection of the order it replaces.
+var rejectTransactionKeys = []string{
+	"orderRejectTransaction",
+	"orderCancelRejectTransaction",
+}
+
+// newAPIError builds an *APIError from a response with a status code of
+// 400 or greater. Bodies which are not json still produce an *APIError
+// with an empty ErrorMsg.
+func newAPIError(response *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		RequestID:  response.Header.Get("RequestID"),
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return apiErr
+	}
+
+	// errors are ignored, any field which does not decode is left empty
+	json.Unmarshal(body, &apiErr.ErrorMsg)
+	json.Unmarshal(fields["lastTransactionID"], &apiErr.LastTransactionID)
+	json.Unmarshal(fields["relatedTransactionIDs"], &apiErr.RelatedTransactionIDs)
+
+	// the name of the rejecting transaction depends on the endpoint,
+	// i.e. "orderRejectTransaction" or "tradeClientExtensionsModifyRejectTransaction"
+	var keys []string
+	for key, value := range fields {
+		if strings.HasSuffix(key, "RejectTransaction") {
+			if apiErr.RejectTransactions == nil {
+				apiErr.RejectTransactions = make(map[string]json.RawMessage)
+			}
+			apiErr.RejectTransactions[key] = value
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		apiErr.RejectTransaction = apiErr.RejectTransactions[keys[0]]
+		for _, key := range rejectTransactionKeys {
+			if value, ok := apiErr.RejectTransactions[key]; ok {
+				apiErr.RejectTransaction = value
+				break
+			}
+		}
+	}
+
+	return apiErr
+}
@@ -0,0 +1,115 @@
+package oanda_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestAPIErrorStatusCodes(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusBadRequest, oanda.ErrBadRequest},
+		{http.StatusUnauthorized, oanda.ErrUnauthorized},
+		{http.StatusForbidden, oanda.ErrForbidden},
+		{http.StatusNotFound, oanda.ErrNotFound},
+		{http.StatusMethodNotAllowed, oanda.ErrMethodNotAllowed},
+		{http.StatusTooManyRequests, oanda.ErrRateLimited},
+		{http.StatusInternalServerError, oanda.ErrServer},
+		{http.StatusServiceUnavailable, oanda.ErrServer},
+	}
+
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("RequestID", "42")
+			w.WriteHeader(test.status)
+			fmt.Fprint(w, `{"errorMessage":"something went wrong"}`)
+		}))
+
+		client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+		_, err := client.GetCandlesBA("USD_CAD", "S5", false)
+		server.Close()
+
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("status %d should match %v but returned: %v", test.status, test.sentinel, err)
+		}
+
+		var apiErr *oanda.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("status %d should return *oanda.APIError but returned: %v", test.status, err)
+		}
+		if apiErr.StatusCode != test.status || apiErr.RequestID != "42" || apiErr.ErrorMessage != "something went wrong" {
+			t.Errorf("status %d returned incomplete *oanda.APIError: %+v", test.status, apiErr)
+		}
+	}
+}
+
+func TestAPIErrorRejectTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+	"orderRejectTransaction": {"id": "7", "type": "MARKET_ORDER_REJECT", "rejectReason": "INSUFFICIENT_MARGIN"},
+	"relatedTransactionIDs": ["7"],
+	"lastTransactionID": "7",
+	"errorCode": "INSUFFICIENT_MARGIN",
+	"errorMessage": "Insufficient margin"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+	_, err := client.GetAccountSummary()
+
+	var apiErr *oanda.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetAccountSummary() should return *oanda.APIError but returned: %v", err)
+	}
+	if apiErr.ErrorCode != "INSUFFICIENT_MARGIN" || apiErr.LastTransactionID != "7" || len(apiErr.RelatedTransactionIDs) != 1 {
+		t.Errorf("*oanda.APIError is missing fields: %+v", apiErr)
+	}
+	if len(apiErr.RejectTransaction) == 0 {
+		t.Error("*oanda.APIError should include the rejecting transaction")
+	}
+}
+
+func TestAPIErrorSeveralRejectTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+	"takeProfitOrderRejectTransaction": {"id": "8", "type": "TAKE_PROFIT_ORDER_REJECT", "rejectReason": "PRICE_INVALID"},
+	"stopLossOrderRejectTransaction": {"id": "9", "type": "STOP_LOSS_ORDER_REJECT", "rejectReason": "PRICE_INVALID"},
+	"orderCancelRejectTransaction": {"id": "10", "type": "ORDER_CANCEL_REJECT", "rejectReason": "ORDER_DOESNT_EXIST"},
+	"orderRejectTransaction": {"id": "11", "type": "LIMIT_ORDER_REJECT", "rejectReason": "PRICE_INVALID"},
+	"errorMessage": "Invalid price"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	// map iteration order is random so decode the response several times
+	for i := 0; i < 20; i++ {
+		_, err := client.GetAccountSummary()
+
+		var apiErr *oanda.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("GetAccountSummary() should return *oanda.APIError but returned: %v", err)
+		}
+		if len(apiErr.RejectTransactions) != 4 {
+			t.Fatalf("*oanda.APIError should include all 4 reject transactions but has: %d", len(apiErr.RejectTransactions))
+		}
+		tx, err := apiErr.Transaction()
+		if err != nil {
+			t.Fatalf("Transaction() produced an error: %v", err)
+		}
+		if _, ok := tx.(*oanda.LimitOrderRejectTransaction); !ok {
+			t.Fatalf("Transaction() should always return the order reject transaction but returned: %T", tx)
+		}
+	}
+}
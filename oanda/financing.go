@@ -0,0 +1,85 @@
+package oanda
+
+import (
+	"fmt"
+	"time"
+)
+
+// days of the week as sent in InstruDaysOfWeek.DayOfWeek
+var financingWeekdays = map[string]time.Weekday{
+	"SUNDAY":    time.Sunday,
+	"MONDAY":    time.Monday,
+	"TUESDAY":   time.Tuesday,
+	"WEDNESDAY": time.Wednesday,
+	"THURSDAY":  time.Thursday,
+	"FRIDAY":    time.Friday,
+	"SATURDAY":  time.Saturday,
+}
+
+// financingScale is the number of decimal places of an estimated financing
+// charge, the precision of Oanda's account amounts.
+const financingScale = 4
+
+/*
+DaysCharged returns the number of days of financing charged for holding a
+position from from to to. Financing is charged at each daily rollover, 17:00
+in New York, after from and up to and including to, for the number of days
+FinancingDaysOfWeek lists for the rollover's day of the week. Days of the week
+which are not listed are not charged.
+*/
+func (f InstruFinancing) DaysCharged(from, to time.Time) (int, error) {
+	charged := make(map[time.Weekday]int, len(f.FinancingDaysOfWeek))
+	for _, day := range f.FinancingDaysOfWeek {
+		weekday, ok := financingWeekdays[day.DayOfWeek]
+		if !ok {
+			return 0, fmt.Errorf("error: unknown financing day of week %q", day.DayOfWeek)
+		}
+		charged[weekday] = day.DaysCharged
+	}
+
+	alignment := DefaultAlignment()
+	days := 0
+	for rollover := alignment.Next(GranularityD, from); !rollover.After(to); rollover = alignment.Next(GranularityD, rollover) {
+		days += charged[rollover.In(alignment.Timezone).Weekday()]
+	}
+	return days, nil
+}
+
+/*
+EstimateFinancing returns an estimate of the financing paid or received for
+holding a position of units in the instrument from from to to, in the
+account's currency and rounded to 4 decimal places. A negative amount is
+paid and a positive amount is received.
+
+The long rate is used for positive units and the short rate for negative
+units, charged for each day returned by InstruFinancing.DaysCharged as
+
+	|units| * price * rate * days / 365 * conversion
+
+price is the instrument's price and conversion converts its quote currency
+into the account's currency, i.e. QuoteHomeConversionFactors.PositiveUnits,
+and is 1 when they are the same. Oanda charges financing at each rollover's
+prices, so the estimate assumes they do not change.
+*/
+func (i InstruDetails) EstimateFinancing(units, price, conversion Decimal, from, to time.Time) (Decimal, error) {
+	if units.IsZero() {
+		return Decimal{}, nil
+	}
+
+	rate := i.Financing.LongRate
+	if units.Sign() < 0 {
+		rate = i.Financing.ShortRate
+	}
+	annual, err := ParseDecimal(rate)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("error parsing %s financing rate: %w", i.Name, err)
+	}
+
+	days, err := i.Financing.DaysCharged(from, to)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	financing := units.Abs().Mul(price).Mul(annual).Mul(NewDecimal(int64(days), 0)).Mul(conversion)
+	return financing.Div(NewDecimal(365, 0), financingScale), nil
+}
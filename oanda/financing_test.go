@@ -0,0 +1,121 @@
+package oanda_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+const instrumentJSON = `{
+	"name": "EUR_USD",
+	"type": "CURRENCY",
+	"displayName": "EUR/USD",
+	"pipLocation": -4,
+	"displayPrecision": 5,
+	"tradeUnitsPrecision": 0,
+	"minimumTradeSize": "1",
+	"maximumTrailingStopDistance": "1.00000",
+	"minimumGuaranteedStopLossDistance": "0.0010",
+	"minimumTrailingStopDistance": "0.00050",
+	"maximumPositionSize": "0",
+	"maximumOrderUnits": "100000000",
+	"marginRate": "0.0333",
+	"commission": {"commission": "0.0", "unitsTraded": "1", "minimumCommission": "0.0"},
+	"guaranteedStopLossOrderMode": "ALLOWED",
+	"guaranteedStopLossOrderExecutionPremium": "0.00015",
+	"guaranteedStopLossOrderLevelRestriction": {"volume": "2500000", "priceRange": "0.00010"},
+	"financing": {
+		"longRate": "-0.0250",
+		"shortRate": "0.0050",
+		"financingDaysOfWeek": [
+			{"dayOfWeek": "MONDAY", "daysCharged": 1},
+			{"dayOfWeek": "TUESDAY", "daysCharged": 1},
+			{"dayOfWeek": "WEDNESDAY", "daysCharged": 3},
+			{"dayOfWeek": "THURSDAY", "daysCharged": 1},
+			{"dayOfWeek": "FRIDAY", "daysCharged": 1},
+			{"dayOfWeek": "SATURDAY", "daysCharged": 0},
+			{"dayOfWeek": "SUNDAY", "daysCharged": 0}
+		]
+	},
+	"tags": [{"type": "ASSET_CLASS", "name": "CURRENCY"}]
+}`
+
+func TestInstrumentDetails(t *testing.T) {
+	var instrument oanda.InstruDetails
+	if err := json.Unmarshal([]byte(instrumentJSON), &instrument); err != nil {
+		t.Fatalf("json.Unmarshal() produced an error: %v", err)
+	}
+
+	if instrument.MinimumGuaranteedStopLossDistance != "0.0010" ||
+		instrument.GuaranteedStopLossOrderExecutionPremium != "0.00015" ||
+		instrument.GuaranteedStopLossOrderLevelRestriction.Volume != "2500000" ||
+		instrument.Commission.UnitsTraded != "1" {
+		t.Errorf("instrument fields were not decoded: %+v", instrument)
+	}
+	if len(instrument.Financing.FinancingDaysOfWeek) != 7 || instrument.Financing.FinancingDaysOfWeek[2].DaysCharged != 3 {
+		t.Errorf("financing days were not decoded: %+v", instrument.Financing)
+	}
+
+	gsl := oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1", OnFill: oanda.OnFill{
+		GuaranteedStopLossOnFill: &oanda.GuaranteedStopLossDetails{Distance: "0.0005"},
+	}}
+	if err := instrument.ValidateOrder(gsl); err == nil {
+		t.Error("ValidateOrder() should fail for a guaranteed stop loss distance below the minimum")
+	}
+}
+
+func TestEstimateFinancing(t *testing.T) {
+	var instrument oanda.InstruDetails
+	if err := json.Unmarshal([]byte(instrumentJSON), &instrument); err != nil {
+		t.Fatalf("json.Unmarshal() produced an error: %v", err)
+	}
+
+	// midday Monday to midday the next Monday in New York is 7 rollovers,
+	// Wednesday's is charged 3 days and the weekend's none
+	from := time.Date(2024, 7, 1, 16, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	days, err := instrument.Financing.DaysCharged(from, to)
+	if err != nil {
+		t.Fatalf("DaysCharged() produced an error: %v", err)
+	}
+	if days != 7 {
+		t.Errorf("DaysCharged() should be 7 but is: %d", days)
+	}
+
+	// a rollover at to is charged, one at from is not
+	rollover := time.Date(2024, 7, 3, 21, 0, 0, 0, time.UTC)
+	if days, _ := instrument.Financing.DaysCharged(rollover.Add(-time.Hour), rollover); days != 3 {
+		t.Errorf("Wednesday's rollover should be charged 3 days but is: %d", days)
+	}
+	if days, _ := instrument.Financing.DaysCharged(rollover, rollover.Add(time.Hour)); days != 0 {
+		t.Errorf("a rollover at from should not be charged but is: %d days", days)
+	}
+
+	d := oanda.MustParseDecimal
+	tests := []struct {
+		units string
+		want  string
+	}{
+		// 10000 * 1.08 * -0.025 * 7 / 365
+		{"10000", "-5.1781"},
+		// 10000 * 1.08 * 0.005 * 7 / 365
+		{"-10000", "1.0356"},
+		{"0", "0"},
+	}
+	for _, tt := range tests {
+		got, err := instrument.EstimateFinancing(d(tt.units), d("1.08"), d("1"), from, to)
+		if err != nil {
+			t.Fatalf("EstimateFinancing() produced an error: %v", err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("EstimateFinancing(%s) should be %s but is: %s", tt.units, tt.want, got)
+		}
+	}
+
+	instrument.Financing.FinancingDaysOfWeek[0].DayOfWeek = "Funday"
+	if _, err := instrument.EstimateFinancing(d("1"), d("1.08"), d("1"), from, to); err == nil {
+		t.Error("EstimateFinancing() should fail for an unknown day of the week")
+	}
+}
@@ -0,0 +1,238 @@
+package oanda
+
+import (
+	"fmt"
+	"time"
+)
+
+// Granularity is the length of time each candlestick covers, see
+// [Instrument Endpoints] for the candlestick granularities Oanda supports.
+//
+// [Instrument Endpoints]: https://developer.oanda.com/rest-live-v20/instrument-ep/
+type Granularity string
+
+const (
+	GranularityS5  Granularity = "S5"
+	GranularityS10 Granularity = "S10"
+	GranularityS15 Granularity = "S15"
+	GranularityS30 Granularity = "S30"
+	GranularityM1  Granularity = "M1"
+	GranularityM2  Granularity = "M2"
+	GranularityM4  Granularity = "M4"
+	GranularityM5  Granularity = "M5"
+	GranularityM10 Granularity = "M10"
+	GranularityM15 Granularity = "M15"
+	GranularityM30 Granularity = "M30"
+	GranularityH1  Granularity = "H1"
+	GranularityH2  Granularity = "H2"
+	GranularityH3  Granularity = "H3"
+	GranularityH4  Granularity = "H4"
+	GranularityH6  Granularity = "H6"
+	GranularityH8  Granularity = "H8"
+	GranularityH12 Granularity = "H12"
+	GranularityD   Granularity = "D"
+	GranularityW   Granularity = "W"
+	GranularityM   Granularity = "M"
+)
+
+// length of each granularity's candles. Months are rounded up to 31 days
+// so that a duration is never shorter than the candle it describes.
+var granularityDurations = map[Granularity]time.Duration{
+	GranularityS5:  5 * time.Second,
+	GranularityS10: 10 * time.Second,
+	GranularityS15: 15 * time.Second,
+	GranularityS30: 30 * time.Second,
+	GranularityM1:  time.Minute,
+	GranularityM2:  2 * time.Minute,
+	GranularityM4:  4 * time.Minute,
+	GranularityM5:  5 * time.Minute,
+	GranularityM10: 10 * time.Minute,
+	GranularityM15: 15 * time.Minute,
+	GranularityM30: 30 * time.Minute,
+	GranularityH1:  time.Hour,
+	GranularityH2:  2 * time.Hour,
+	GranularityH3:  3 * time.Hour,
+	GranularityH4:  4 * time.Hour,
+	GranularityH6:  6 * time.Hour,
+	GranularityH8:  8 * time.Hour,
+	GranularityH12: 12 * time.Hour,
+	GranularityD:   24 * time.Hour,
+	GranularityW:   7 * 24 * time.Hour,
+	GranularityM:   31 * 24 * time.Hour,
+}
+
+// ParseGranularity returns s as a Granularity, or an error when Oanda does
+// not support it.
+func ParseGranularity(s string) (Granularity, error) {
+	g := Granularity(s)
+	if err := g.Validate(); err != nil {
+		return "", err
+	}
+	return g, nil
+}
+
+// Validate returns an error when Oanda does not support the granularity.
+func (g Granularity) Validate() error {
+	if _, ok := granularityDurations[g]; !ok {
+		return fmt.Errorf("unknown granularity %q", string(g))
+	}
+	return nil
+}
+
+// Duration returns the length of the granularity's candles, or zero for an
+// unknown granularity. D is 24 hours and M is 31 days, the longest a
+// candle can be, as the length of days and months varies.
+func (g Granularity) Duration() time.Duration {
+	return granularityDurations[g]
+}
+
+// Truncate returns the open time of the candle containing t, using Oanda's
+// default alignment.
+func (g Granularity) Truncate(t time.Time) time.Time {
+	return DefaultAlignment().Truncate(g, t)
+}
+
+// Next returns the open time of the candle after the one containing t, using
+// Oanda's default alignment.
+func (g Granularity) Next(t time.Time) time.Time {
+	return DefaultAlignment().Next(g, t)
+}
+
+// Prev returns the open time of the candle before the one containing t, using
+// Oanda's default alignment.
+func (g Granularity) Prev(t time.Time) time.Time {
+	return DefaultAlignment().Prev(g, t)
+}
+
+/*
+Alignment is where candles longer than an hour start, the same as the
+dailyAlignment, alignmentTimezone and weeklyAlignment query parameters of
+the candles endpoint.
+
+Days start at DailyAlignment o'clock in Timezone and H2 to H12 candles are
+counted from the start of the day. Weeks start at the start of the day on
+WeeklyAlignment. Months start at the start of the trading day of the 1st,
+which is the previous day when DailyAlignment is not midnight.
+*/
+type Alignment struct {
+	// DailyAlignment is the hour of day (0 to 23) days start at.
+	DailyAlignment int
+	// Timezone is the timezone DailyAlignment is in, nil means UTC.
+	Timezone *time.Location
+	// WeeklyAlignment is the day of the week weeks start on.
+	WeeklyAlignment time.Weekday
+}
+
+// newYork is the default alignment timezone, it falls back to a fixed
+// offset when the system has no timezone database.
+var newYork = func() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}()
+
+// DefaultAlignment returns the alignment Oanda uses when none is requested,
+// days start at 17:00 in New York and weeks start on Friday.
+func DefaultAlignment() Alignment {
+	return Alignment{
+		DailyAlignment:  17,
+		Timezone:        newYork,
+		WeeklyAlignment: time.Friday,
+	}
+}
+
+func (a Alignment) location() *time.Location {
+	if a.Timezone == nil {
+		return time.UTC
+	}
+	return a.Timezone
+}
+
+// dayStart returns the start of the day containing t.
+func (a Alignment) dayStart(t time.Time) time.Time {
+	local := t.In(a.location())
+	start := time.Date(local.Year(), local.Month(), local.Day(), a.DailyAlignment, 0, 0, 0, a.location())
+	if start.After(local) {
+		start = time.Date(local.Year(), local.Month(), local.Day()-1, a.DailyAlignment, 0, 0, 0, a.location())
+	}
+	return start
+}
+
+// addDays returns the start of the day days after the day starting at start.
+func (a Alignment) addDays(start time.Time, days int) time.Time {
+	return time.Date(start.Year(), start.Month(), start.Day()+days, a.DailyAlignment, 0, 0, 0, a.location())
+}
+
+// monthStart returns the start of the month containing t, and of the month
+// after it.
+func (a Alignment) monthStart(t time.Time) (time.Time, time.Time) {
+	// a day starting before midnight is the trading day of the next date
+	offset := 0
+	if a.DailyAlignment > 0 {
+		offset = 1
+	}
+	day := a.addDays(a.dayStart(t), offset)
+	start := time.Date(day.Year(), day.Month(), 1-offset, a.DailyAlignment, 0, 0, 0, a.location())
+	next := time.Date(day.Year(), day.Month()+1, 1-offset, a.DailyAlignment, 0, 0, 0, a.location())
+	return start, next
+}
+
+// Truncate returns the open time of the g candle containing t, in t's
+// location. t is returned unchanged for an unknown granularity.
+func (a Alignment) Truncate(g Granularity, t time.Time) time.Time {
+	start, _ := a.bounds(g, t)
+	return start.In(t.Location())
+}
+
+// Next returns the open time of the g candle after the one containing t, in
+// t's location. t is returned unchanged for an unknown granularity.
+func (a Alignment) Next(g Granularity, t time.Time) time.Time {
+	_, next := a.bounds(g, t)
+	return next.In(t.Location())
+}
+
+// Prev returns the open time of the g candle before the one containing t, in
+// t's location. t is returned unchanged for an unknown granularity.
+func (a Alignment) Prev(g Granularity, t time.Time) time.Time {
+	if g.Validate() != nil {
+		return t
+	}
+	start, _ := a.bounds(g, t)
+	return a.Truncate(g, start.Add(-time.Nanosecond)).In(t.Location())
+}
+
+// bounds returns the open time of the g candle containing t and of the candle
+// after it.
+func (a Alignment) bounds(g Granularity, t time.Time) (time.Time, time.Time) {
+	d := g.Duration()
+	switch {
+	case d == 0:
+		return t, t
+	case d <= time.Hour:
+		start := t.Truncate(d)
+		return start, start.Add(d)
+	case g == GranularityD:
+		start := a.dayStart(t)
+		return start, a.addDays(start, 1)
+	case g == GranularityW:
+		start := a.dayStart(t)
+		for start.Weekday() != a.WeeklyAlignment {
+			start = a.addDays(start, -1)
+		}
+		return start, a.addDays(start, 7)
+	case g == GranularityM:
+		return a.monthStart(t)
+	}
+
+	// H2 to H12 count from the start of the day, the last candle of a day
+	// which is not 24 hours long is cut short
+	day := a.dayStart(t)
+	start := day.Add(t.Sub(day) / d * d)
+	next := start.Add(d)
+	if end := a.addDays(day, 1); next.After(end) {
+		next = end
+	}
+	return start, next
+}
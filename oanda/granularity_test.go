@@ -0,0 +1,78 @@
+package oanda_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestParseGranularity(t *testing.T) {
+	g, err := oanda.ParseGranularity("H4")
+	if err != nil {
+		t.Fatalf("ParseGranularity() produced an error: %v", err)
+	}
+	if g != oanda.GranularityH4 || g.Duration() != 4*time.Hour {
+		t.Errorf("ParseGranularity() should return H4 but returned: %s (%s)", g, g.Duration())
+	}
+
+	for _, in := range []string{"", "S6", "h4", "Y"} {
+		if _, err := oanda.ParseGranularity(in); err == nil {
+			t.Errorf("ParseGranularity(%q) should return an error", in)
+		}
+	}
+}
+
+func TestGranularityTruncate(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+
+	// the default alignment starts days at 17:00 in New York, which is
+	// 21:00 UTC in summer and 22:00 UTC in winter
+	tests := []struct {
+		g                oanda.Granularity
+		t                string
+		prev, open, next string
+	}{
+		{oanda.GranularityM5, "2024-07-03T10:17:23Z", "2024-07-03T10:10:00Z", "2024-07-03T10:15:00Z", "2024-07-03T10:20:00Z"},
+		{oanda.GranularityH4, "2024-07-03T10:17:23Z", "2024-07-03T05:00:00Z", "2024-07-03T09:00:00Z", "2024-07-03T13:00:00Z"},
+		{oanda.GranularityD, "2024-07-03T10:17:23Z", "2024-07-01T21:00:00Z", "2024-07-02T21:00:00Z", "2024-07-03T21:00:00Z"},
+		{oanda.GranularityW, "2024-07-03T10:17:23Z", "2024-06-21T21:00:00Z", "2024-06-28T21:00:00Z", "2024-07-05T21:00:00Z"},
+		{oanda.GranularityM, "2024-07-03T10:17:23Z", "2024-05-31T21:00:00Z", "2024-06-30T21:00:00Z", "2024-07-31T21:00:00Z"},
+		// the 1st's trading day starts the evening before
+		{oanda.GranularityM, "2024-06-30T22:00:00Z", "2024-05-31T21:00:00Z", "2024-06-30T21:00:00Z", "2024-07-31T21:00:00Z"},
+		// the day the clocks go back is 25 hours, its last H12 candle is 1 hour
+		{oanda.GranularityH12, "2024-11-03T21:30:00Z", "2024-11-03T09:00:00Z", "2024-11-03T21:00:00Z", "2024-11-03T22:00:00Z"},
+		{oanda.GranularityD, "2024-11-03T21:30:00Z", "2024-11-01T21:00:00Z", "2024-11-02T21:00:00Z", "2024-11-03T22:00:00Z"},
+	}
+	for _, tt := range tests {
+		ts := at(tt.t)
+		if got := tt.g.Truncate(ts); !got.Equal(at(tt.open)) {
+			t.Errorf("%s.Truncate(%s) should be %s but is: %s", tt.g, tt.t, tt.open, got.UTC())
+		}
+		if got := tt.g.Next(ts); !got.Equal(at(tt.next)) {
+			t.Errorf("%s.Next(%s) should be %s but is: %s", tt.g, tt.t, tt.next, got.UTC())
+		}
+		if got := tt.g.Prev(ts); !got.Equal(at(tt.prev)) {
+			t.Errorf("%s.Prev(%s) should be %s but is: %s", tt.g, tt.t, tt.prev, got.UTC())
+		}
+	}
+
+	utc := oanda.Alignment{WeeklyAlignment: time.Monday}
+	ts := at("2024-07-03T10:17:23Z")
+	if got := utc.Truncate(oanda.GranularityW, ts); !got.Equal(at("2024-07-01T00:00:00Z")) {
+		t.Errorf("weeks aligned to Monday in UTC should start on 2024-07-01 but start: %s", got)
+	}
+	if got := utc.Next(oanda.GranularityM, ts); !got.Equal(at("2024-08-01T00:00:00Z")) {
+		t.Errorf("months aligned to midnight in UTC should start on the 1st but start: %s", got)
+	}
+	if got := oanda.Granularity("S6").Truncate(ts); !got.Equal(ts) {
+		t.Errorf("an unknown granularity should return t unchanged but returned: %s", got)
+	}
+}
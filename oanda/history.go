@@ -0,0 +1,219 @@
+package oanda
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// HistoryRequest describes a range of historical candles to download
+// with GetHistory or StreamHistory.
+type HistoryRequest struct {
+	Instrument string
+	// Granularity of the candlesticks, i.e. GranularityM1 for 1 minute candles.
+	Granularity Granularity
+	// Price components to download, any combination of "M", "B" and "A".
+	// Defaults to "M".
+	Price string
+	// From is the start of the range to download, inclusive.
+	From time.Time
+	// To is the end of the range to download. When it is zero the last
+	// request is sent without a to, so Oanda returns everything up to its
+	// own clock's now, a to after which it rejects.
+	To time.Time
+	// Concurrency is the maximum number of requests in flight at once.
+	// Defaults to 1.
+	Concurrency int
+	// RequestsPerSecond limits how often a request is started, zero
+	// means no limit. Oanda allows at most 120 requests per second.
+	RequestsPerSecond int
+}
+
+// window is one request's worth of a HistoryRequest, to is zero for the
+// last window of a request without a To.
+type window struct {
+	from time.Time
+	to   time.Time
+}
+
+// windows splits the request into consecutive time ranges which each
+// hold at most 5000 candles.
+func (r *HistoryRequest) windows() ([]window, error) {
+	if err := r.Granularity.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid history request: %w", err)
+	}
+	if r.From.IsZero() {
+		return nil, fmt.Errorf("invalid history request: from must be set")
+	}
+
+	to := r.To
+	if to.IsZero() {
+		// only used to size the windows, a local clock ahead of Oanda's
+		// must not be sent as to
+		to = time.Now()
+	}
+	if !to.After(r.From) {
+		return nil, fmt.Errorf("invalid history request: to (%s) must be after from (%s)", to, r.From)
+	}
+
+	// a window of maxCandleCount candles never holds more than that, as
+	// Duration is the longest a candle can be
+	size := r.Granularity.Duration() * maxCandleCount
+	var windows []window
+	for from := r.From; from.Before(to); from = from.Add(size) {
+		end := from.Add(size)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, window{from: from, to: end})
+	}
+	if r.To.IsZero() {
+		windows[len(windows)-1].to = time.Time{}
+	}
+	return windows, nil
+}
+
+// GetHistory method downloads every candle for the request's time range,
+// making as many requests to the candles endpoint as needed. The candles
+// are returned in order with duplicates at window boundaries removed.
+func (c *Client) GetHistory(ctx context.Context, req *HistoryRequest) ([]OHLC, error) {
+	candles, errc := c.StreamHistory(ctx, req)
+
+	var history []OHLC
+	for candle := range candles {
+		history = append(history, candle)
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// StreamHistory method is the same as GetHistory but candles are sent on the
+// returned channel, in order, as soon as they are downloaded. The candle
+// channel is closed once the download finishes, after which the error
+// channel receives the first error encountered or nil. Cancel ctx to stop
+// the download early if the candle channel will not be drained.
+func (c *Client) StreamHistory(ctx context.Context, req *HistoryRequest) (<-chan OHLC, <-chan error) {
+	out := make(chan OHLC)
+	errc := make(chan error, 1)
+
+	windows, err := req.windows()
+	if err != nil {
+		close(out)
+		errc <- err
+		return out, errc
+	}
+
+	concurrency := req.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	// each window's candles are delivered on its own channel so they can
+	// be emitted in order no matter which request finishes first
+	type result struct {
+		candles []OHLC
+		err     error
+	}
+	results := make([]chan result, len(windows))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	// slots bounds the number of windows fetched but not yet emitted
+	slots := make(chan struct{}, concurrency)
+
+	go func() {
+		var ticker *time.Ticker
+		if req.RequestsPerSecond > 0 {
+			ticker = time.NewTicker(time.Second / time.Duration(req.RequestsPerSecond))
+			defer ticker.Stop()
+		}
+
+		for i, w := range windows {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			if ticker != nil && i > 0 {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			go func(i int, w window) {
+				candlesReq := &CandlesRequest{
+					Price:       req.Price,
+					Granularity: req.Granularity,
+					From:        w.from,
+					To:          w.to,
+				}
+				if w.to.IsZero() {
+					// without a to Oanda returns count candles from from
+					candlesReq.Count = maxCandleCount
+				}
+				data, err := c.GetCandles(ctx, req.Instrument, candlesReq)
+				if err != nil {
+					results[i] <- result{err: err}
+					return
+				}
+				results[i] <- result{candles: data.Candles}
+			}(i, w)
+		}
+	}()
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+		defer cancel()
+
+		var last time.Time
+		for i := range windows {
+			var res result
+			select {
+			case res = <-results[i]:
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+			if res.err != nil {
+				errc <- res.err
+				return
+			}
+
+			for _, candle := range sortCandles(res.candles) {
+				// skip candles already sent by the previous window
+				if !last.IsZero() && !candle.Time.After(last) {
+					continue
+				}
+				last = candle.Time.Time
+
+				select {
+				case out <- candle:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+			<-slots
+		}
+	}()
+
+	return out, errc
+}
+
+// sortCandles sorts the candles by time in ascending order.
+func sortCandles(candles []OHLC) []OHLC {
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time.Time)
+	})
+	return candles
+}
@@ -0,0 +1,132 @@
+package oanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+// candleServer responds with one S5 candle for every 5 seconds between
+// from and to, both inclusive, so consecutive windows overlap by a candle.
+// Without a to it responds with count candles from from, up to now.
+func candleServer(t *testing.T, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+		if err != nil {
+			t.Errorf("error parsing from: %v", err)
+		}
+		var to time.Time
+		if r.URL.Query().Has("to") {
+			if to, err = time.Parse(time.RFC3339, r.URL.Query().Get("to")); err != nil {
+				t.Errorf("error parsing to: %v", err)
+			}
+		} else {
+			count, err := strconv.Atoi(r.URL.Query().Get("count"))
+			if err != nil {
+				t.Errorf("error parsing count: %v", err)
+			}
+			to = from.Add(time.Duration(count-1) * 5 * time.Second)
+			if now := time.Now(); to.After(now) {
+				to = now
+			}
+		}
+
+		data := oanda.Metadata{Instrument: "EUR_USD", Granularity: "S5"}
+		for ts := from; !ts.After(to); ts = ts.Add(5 * time.Second) {
+			data.Candles = append(data.Candles, oanda.OHLC{Complete: true, Time: oanda.Time{Time: ts}})
+		}
+		json.NewEncoder(w).Encode(data)
+	}))
+}
+
+func TestGetHistory(t *testing.T) {
+	var requests int32
+	server := candleServer(t, &requests)
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	history, err := client.GetHistory(context.Background(), &oanda.HistoryRequest{
+		Instrument:  "EUR_USD",
+		Granularity: "S5",
+		From:        from,
+		To:          from.Add(60000 * time.Second),
+		Concurrency: 3,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory() produced an error: %v", err)
+	}
+
+	// 5000 S5 candles per request means 3 requests for 60000 seconds
+	if requests != 3 {
+		t.Errorf("GetHistory() should make 3 requests but made: %d", requests)
+	}
+	if len(history) != 12001 {
+		t.Fatalf("GetHistory() should return 12001 candles but returned: %d", len(history))
+	}
+	for i, candle := range history {
+		want := from.Add(time.Duration(i) * 5 * time.Second)
+		if !candle.Time.Equal(want) {
+			t.Fatalf("candle %d should be at %s but is at: %s", i, want, candle.Time)
+		}
+	}
+}
+
+func TestGetHistoryUntilNow(t *testing.T) {
+	var requests, openEnded int32
+	candles := candleServer(t, &requests)
+	defer candles.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !r.URL.Query().Has("to") {
+			atomic.AddInt32(&openEnded, 1)
+		}
+		candles.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	from := time.Now().Add(-30000 * time.Second).Truncate(5 * time.Second).UTC()
+	history, err := client.GetHistory(context.Background(), &oanda.HistoryRequest{
+		Instrument:  "EUR_USD",
+		Granularity: "S5",
+		From:        from,
+	})
+	if err != nil {
+		t.Fatalf("GetHistory() produced an error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("GetHistory() should make 2 requests but made: %d", requests)
+	}
+	// a to from the local clock may be ahead of Oanda's, which it rejects
+	if openEnded != 1 {
+		t.Errorf("only the last request should be sent without a to but %d were", openEnded)
+	}
+	// the server only has candles up to its now
+	if len(history) < 6000 || len(history) > 6001 {
+		t.Fatalf("GetHistory() should return every candle up to now but returned: %d", len(history))
+	}
+}
+
+func TestGetHistoryInvalid(t *testing.T) {
+	client := oanda.NewClient()
+
+	_, err := client.GetHistory(context.Background(), &oanda.HistoryRequest{
+		Instrument:  "EUR_USD",
+		Granularity: "S6",
+		From:        time.Now().Add(-time.Hour),
+	})
+	if err == nil {
+		t.Fatal("GetHistory() should fail for an unknown granularity")
+	}
+}
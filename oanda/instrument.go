@@ -0,0 +1,407 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+)
+
+/*
+Instruments is a registry of the instruments an account can trade, used to
+convert between prices and pips, round prices and units to the precision
+Oanda accepts and check orders against each instrument's limits before they
+are sent. See LoadInstruments.
+*/
+type Instruments struct {
+	byName map[string]InstruDetails
+}
+
+// NewInstruments returns a registry of the instruments in list.
+func NewInstruments(list []InstruDetails) *Instruments {
+	r := &Instruments{byName: make(map[string]InstruDetails, len(list))}
+	for _, instrument := range list {
+		r.byName[instrument.Name] = instrument
+	}
+	return r
+}
+
+/*
+LoadInstruments method will return a registry of the tradeable instruments
+for the client's account, see GetAccountInstru.
+
+When cachePath is set the instruments are read from that file instead if it
+is younger than maxAge, or any age when maxAge is zero. Otherwise they are
+fetched from Oanda and written to cachePath. Instruments depend on the
+account's regulatory division so use one cache file per division.
+
+endpoint: /v3/accounts/{accountID}/instruments
+*/
+func (c *Client) LoadInstruments(ctx context.Context, cachePath string, maxAge time.Duration) (*Instruments, error) {
+	if cachePath != "" {
+		if list, ok := readInstrumentCache(cachePath, maxAge); ok {
+			return NewInstruments(list), nil
+		}
+	}
+
+	accountInstru, err := c.GetAccountInstruContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := writeInstrumentCache(cachePath, accountInstru.List); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewInstruments(accountInstru.List), nil
+}
+
+// readInstrumentCache returns the instruments in the cache file, false is
+// returned when the file is missing, too old or cannot be decoded.
+func readInstrumentCache(path string, maxAge time.Duration) ([]InstruDetails, bool) {
+	info, err := os.Stat(path)
+	if err != nil || (maxAge > 0 && time.Since(info.ModTime()) > maxAge) {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var list []InstruDetails
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+// writeInstrumentCache writes the instruments to the cache file, replacing
+// it in one step so a reader never sees a partial file.
+func writeInstrumentCache(path string, list []InstruDetails) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("error marshaling json: %s", err.Error())
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error writing instrument cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing instrument cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing instrument cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error writing instrument cache: %w", err)
+	}
+	return nil
+}
+
+// Get returns the instrument called name, i.e. "EUR_USD".
+func (r *Instruments) Get(name string) (InstruDetails, error) {
+	instrument, ok := r.byName[name]
+	if !ok {
+		return InstruDetails{}, fmt.Errorf("unknown instrument %q", name)
+	}
+	return instrument, nil
+}
+
+// List returns every instrument in the registry sorted by name.
+func (r *Instruments) List() []InstruDetails {
+	list := make([]InstruDetails, 0, len(r.byName))
+	for _, instrument := range r.byName {
+		list = append(list, instrument)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+/*
+ValidateOrder checks an order's units, prices and distances against the
+limits of its instrument, see InstruDetails.ValidateOrder. Orders for an
+existing trade have no instrument, use InstruDetails.ValidateOrder with the
+trade's instrument for those.
+*/
+func (r *Instruments) ValidateOrder(order OrderRequest) error {
+	name := orderInstrument(order)
+	if name == "" {
+		return fmt.Errorf("invalid %s order: it has no instrument", order.OrderType())
+	}
+	instrument, err := r.Get(name)
+	if err != nil {
+		return fmt.Errorf("invalid %s order: %w", order.OrderType(), err)
+	}
+	return instrument.ValidateOrder(order)
+}
+
+// orderValue returns the order a pointer to an order request points to.
+func orderValue(order OrderRequest) OrderRequest {
+	if v := reflect.ValueOf(order); v.Kind() == reflect.Pointer && !v.IsNil() {
+		if o, ok := v.Elem().Interface().(OrderRequest); ok {
+			return o
+		}
+	}
+	return order
+}
+
+// orderInstrument returns the instrument of an order which opens a trade.
+func orderInstrument(order OrderRequest) string {
+	switch o := orderValue(order).(type) {
+	case MarketOrderRequest:
+		return o.Instrument
+	case LimitOrderRequest:
+		return o.Instrument
+	case StopOrderRequest:
+		return o.Instrument
+	case MarketIfTouchedOrderRequest:
+		return o.Instrument
+	}
+	return ""
+}
+
+// PipSize returns the price change of one pip, i.e. 0.0001 for EUR_USD.
+func (i InstruDetails) PipSize() Decimal {
+	return NewDecimal(1, 0).Shift(i.PipLocation)
+}
+
+// ToPips returns a price distance in pips, i.e. 0.0025 is 25 pips for EUR_USD.
+func (i InstruDetails) ToPips(distance Decimal) Decimal {
+	return distance.Shift(-i.PipLocation)
+}
+
+// FromPips returns the price distance of pips, i.e. 25 pips is 0.0025 for EUR_USD.
+func (i InstruDetails) FromPips(pips Decimal) Decimal {
+	return pips.Shift(i.PipLocation)
+}
+
+// RoundPrice rounds a price or price distance to the instrument's display
+// precision, the most decimal places Oanda accepts.
+func (i InstruDetails) RoundPrice(price Decimal) Decimal {
+	return price.Round(i.DisplayPrecision)
+}
+
+// RoundUnits truncates units to the instrument's trade units precision,
+// rounding towards zero so the units never grow.
+func (i InstruDetails) RoundUnits(units Decimal) Decimal {
+	return units.Truncate(i.TradeUnitsPrecision)
+}
+
+/*
+PipValue returns the value of one pip for a position of units in the
+account's currency. rate converts the instrument's quote currency into the
+account's currency, i.e. QuoteHomeConversionFactors.PositiveUnits, and is 1
+when they are the same.
+*/
+func (i InstruDetails) PipValue(units, rate Decimal) Decimal {
+	return units.Abs().Mul(i.PipSize()).Mul(rate)
+}
+
+// instrumentLimits holds the instrument's limits as Decimals, zero means
+// the limit was not sent.
+type instrumentLimits struct {
+	minimumTradeSize                  Decimal
+	maximumOrderUnits                 Decimal
+	maximumPositionSize               Decimal
+	minimumTrailingStopDistance       Decimal
+	maximumTrailingStopDistance       Decimal
+	minimumGuaranteedStopLossDistance Decimal
+}
+
+func (i InstruDetails) limits() (instrumentLimits, error) {
+	var p decimalParser
+	limits := instrumentLimits{
+		minimumTradeSize:                  p.parse(i.MinimumTradeSize),
+		maximumOrderUnits:                 p.parse(i.MaximumOrderUnits),
+		maximumPositionSize:               p.parse(i.MaximumPositionSize),
+		minimumTrailingStopDistance:       p.parse(i.MinimumTrailingStopDistance),
+		maximumTrailingStopDistance:       p.parse(i.MaximumTrailingStopDistance),
+		minimumGuaranteedStopLossDistance: p.parse(i.MinimumGuaranteedStopLossDistance),
+	}
+	if p.err != nil {
+		return limits, fmt.Errorf("error parsing %s limits: %w", i.Name, p.err)
+	}
+	return limits, nil
+}
+
+/*
+ValidateUnits checks that the units of an order are not zero, have no more
+decimal places than the instrument's trade units precision and are within
+its minimum trade size, maximum order units and maximum position size.
+*/
+func (i InstruDetails) ValidateUnits(units Decimal) error {
+	limits, err := i.limits()
+	if err != nil {
+		return err
+	}
+
+	size := units.Abs()
+	switch {
+	case units.IsZero():
+		return fmt.Errorf("invalid %s units: units must not be zero", i.Name)
+	case !units.Truncate(i.TradeUnitsPrecision).Equal(units):
+		return fmt.Errorf("invalid %s units: %s has more than %d decimal places", i.Name, units, i.TradeUnitsPrecision)
+	case size.Cmp(limits.minimumTradeSize) < 0:
+		return fmt.Errorf("invalid %s units: %s is less than the minimum trade size %s", i.Name, units, i.MinimumTradeSize)
+	case !limits.maximumOrderUnits.IsZero() && size.Cmp(limits.maximumOrderUnits) > 0:
+		return fmt.Errorf("invalid %s units: %s is more than the maximum order units %s", i.Name, units, i.MaximumOrderUnits)
+	case !limits.maximumPositionSize.IsZero() && size.Cmp(limits.maximumPositionSize) > 0:
+		return fmt.Errorf("invalid %s units: %s is more than the maximum position size %s", i.Name, units, i.MaximumPositionSize)
+	}
+	return nil
+}
+
+// ValidatePrice checks that a price or price distance is positive and has no
+// more decimal places than the instrument's display precision.
+func (i InstruDetails) ValidatePrice(price Decimal) error {
+	if price.Sign() <= 0 {
+		return fmt.Errorf("invalid %s price: %s must be positive", i.Name, price)
+	}
+	if !price.Round(i.DisplayPrecision).Equal(price) {
+		return fmt.Errorf("invalid %s price: %s has more than %d decimal places", i.Name, price, i.DisplayPrecision)
+	}
+	return nil
+}
+
+// ValidateTrailingStopDistance checks a trailing stop loss distance with
+// ValidatePrice and against the instrument's minimum and maximum trailing
+// stop distance.
+func (i InstruDetails) ValidateTrailingStopDistance(distance Decimal) error {
+	if err := i.ValidatePrice(distance); err != nil {
+		return err
+	}
+	limits, err := i.limits()
+	if err != nil {
+		return err
+	}
+	if distance.Cmp(limits.minimumTrailingStopDistance) < 0 {
+		return fmt.Errorf("invalid %s trailing stop distance: %s is less than the minimum %s", i.Name, distance, i.MinimumTrailingStopDistance)
+	}
+	if !limits.maximumTrailingStopDistance.IsZero() && distance.Cmp(limits.maximumTrailingStopDistance) > 0 {
+		return fmt.Errorf("invalid %s trailing stop distance: %s is more than the maximum %s", i.Name, distance, i.MaximumTrailingStopDistance)
+	}
+	return nil
+}
+
+// ValidateGuaranteedStopLossDistance checks a guaranteed stop loss distance
+// with ValidatePrice and against the instrument's minimum guaranteed stop
+// loss distance.
+func (i InstruDetails) ValidateGuaranteedStopLossDistance(distance Decimal) error {
+	if err := i.ValidatePrice(distance); err != nil {
+		return err
+	}
+	limits, err := i.limits()
+	if err != nil {
+		return err
+	}
+	if distance.Cmp(limits.minimumGuaranteedStopLossDistance) < 0 {
+		return fmt.Errorf("invalid %s guaranteed stop loss distance: %s is less than the minimum %s", i.Name, distance, i.MinimumGuaranteedStopLossDistance)
+	}
+	return nil
+}
+
+// orderCheck collects the checks of an order, keeping the first error.
+type orderCheck struct {
+	instrument InstruDetails
+	err        error
+}
+
+// check runs validate on the decimal s, empty strings are fields which were
+// not set and are skipped.
+func (c *orderCheck) check(field, s string, validate func(Decimal) error) {
+	if s == "" || c.err != nil {
+		return
+	}
+	d, err := ParseDecimal(s)
+	if err != nil {
+		c.err = fmt.Errorf("invalid %s %s: %w", c.instrument.Name, field, err)
+		return
+	}
+	if err := validate(d); err != nil {
+		c.err = fmt.Errorf("%s: %w", field, err)
+	}
+}
+
+// units checks the units of an order which opens a trade, which must be set.
+func (c *orderCheck) units(s string) {
+	if s == "" && c.err == nil {
+		c.err = fmt.Errorf("invalid %s units: units must be set", c.instrument.Name)
+		return
+	}
+	c.check("units", s, c.instrument.ValidateUnits)
+}
+
+func (c *orderCheck) onFill(onFill OnFill) {
+	i := c.instrument
+	if tp := onFill.TakeProfitOnFill; tp != nil {
+		c.check("take profit price", tp.Price, i.ValidatePrice)
+	}
+	if sl := onFill.StopLossOnFill; sl != nil {
+		c.check("stop loss price", sl.Price, i.ValidatePrice)
+		c.check("stop loss distance", sl.Distance, i.ValidatePrice)
+	}
+	if gsl := onFill.GuaranteedStopLossOnFill; gsl != nil {
+		c.check("guaranteed stop loss price", gsl.Price, i.ValidatePrice)
+		c.check("guaranteed stop loss distance", gsl.Distance, i.ValidateGuaranteedStopLossDistance)
+	}
+	if tsl := onFill.TrailingStopLossOnFill; tsl != nil {
+		c.check("trailing stop loss distance", tsl.Distance, i.ValidateTrailingStopDistance)
+	}
+}
+
+/*
+ValidateOrder checks an order against the instrument's limits: units with
+ValidateUnits, prices and stop loss distances with ValidatePrice, guaranteed
+stop loss distances with ValidateGuaranteedStopLossDistance and trailing
+stop loss distances with ValidateTrailingStopDistance, including
+the orders to create on fill. Units must be set, other empty fields are
+skipped. An error is returned when the order is for another instrument.
+*/
+func (i InstruDetails) ValidateOrder(order OrderRequest) error {
+	if name := orderInstrument(order); name != "" && name != i.Name {
+		return fmt.Errorf("invalid %s order: it is for %s not %s", order.OrderType(), name, i.Name)
+	}
+
+	c := orderCheck{instrument: i}
+	switch o := orderValue(order).(type) {
+	case MarketOrderRequest:
+		c.units(o.Units)
+		c.check("price bound", o.PriceBound, i.ValidatePrice)
+		c.onFill(o.OnFill)
+	case LimitOrderRequest:
+		c.units(o.Units)
+		c.check("price", o.Price, i.ValidatePrice)
+		c.onFill(o.OnFill)
+	case StopOrderRequest:
+		c.units(o.Units)
+		c.check("price", o.Price, i.ValidatePrice)
+		c.check("price bound", o.PriceBound, i.ValidatePrice)
+		c.onFill(o.OnFill)
+	case MarketIfTouchedOrderRequest:
+		c.units(o.Units)
+		c.check("price", o.Price, i.ValidatePrice)
+		c.check("price bound", o.PriceBound, i.ValidatePrice)
+		c.onFill(o.OnFill)
+	case TakeProfitOrderRequest:
+		c.check("price", o.Price, i.ValidatePrice)
+	case StopLossOrderRequest:
+		c.check("price", o.Price, i.ValidatePrice)
+		c.check("distance", o.Distance, i.ValidatePrice)
+	case GuaranteedStopLossOrderRequest:
+		c.check("price", o.Price, i.ValidatePrice)
+		c.check("distance", o.Distance, i.ValidateGuaranteedStopLossDistance)
+	case TrailingStopLossOrderRequest:
+		c.check("distance", o.Distance, i.ValidateTrailingStopDistance)
+	}
+	return c.err
+}
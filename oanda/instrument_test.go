@@ -0,0 +1,130 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+var eurUSD = oanda.InstruDetails{
+	Name:                        "EUR_USD",
+	PipLocation:                 -4,
+	DisplayPrecision:            5,
+	TradeUnitsPrecision:         0,
+	MinimumTradeSize:            "1",
+	MaximumTrailingStopDistance: "1.00000",
+	MinimumTrailingStopDistance: "0.00050",
+	MaximumPositionSize:         "0",
+	MaximumOrderUnits:           "100000000",
+}
+
+func TestInstrumentConversions(t *testing.T) {
+	d := oanda.MustParseDecimal
+	usdJPY := oanda.InstruDetails{Name: "USD_JPY", PipLocation: -2, DisplayPrecision: 3}
+
+	tests := []struct {
+		name string
+		got  oanda.Decimal
+		want string
+	}{
+		{"pip size", eurUSD.PipSize(), "0.0001"},
+		{"to pips", eurUSD.ToPips(d("0.00255")), "25.5"},
+		{"from pips", eurUSD.FromPips(d("25")), "0.0025"},
+		{"jpy to pips", usdJPY.ToPips(d("0.150")), "15.0"},
+		{"round price", eurUSD.RoundPrice(d("1.0851249")), "1.08512"},
+		{"round units", eurUSD.RoundUnits(d("-1500.9")), "-1500"},
+		{"pip value", eurUSD.PipValue(d("-10000"), d("1")), "1.0000"},
+		{"jpy pip value", usdJPY.PipValue(d("10000"), d("0.0067")), "0.670000"},
+	}
+	for _, tt := range tests {
+		if tt.got.String() != tt.want {
+			t.Errorf("%s should be %s but is: %s", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestInstrumentValidateOrder(t *testing.T) {
+	registry := oanda.NewInstruments([]oanda.InstruDetails{eurUSD})
+
+	valid := []oanda.OrderRequest{
+		oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "-100"},
+		&oanda.LimitOrderRequest{Instrument: "EUR_USD", Units: "100", Price: "1.08512", OnFill: oanda.OnFill{
+			StopLossOnFill:         &oanda.StopLossDetails{Distance: "0.0025"},
+			TrailingStopLossOnFill: &oanda.TrailingStopLossDetails{Distance: "0.0050"},
+		}},
+	}
+	for _, order := range valid {
+		if err := registry.ValidateOrder(order); err != nil {
+			t.Errorf("ValidateOrder() should pass for %+v but produced an error: %v", order, err)
+		}
+	}
+
+	invalid := map[string]oanda.OrderRequest{
+		"unknown instrument": oanda.MarketOrderRequest{Instrument: "XAU_EUR", Units: "1"},
+		"no instrument":      oanda.TakeProfitOrderRequest{TradeID: "1", Price: "1.1"},
+		"no units":           oanda.MarketOrderRequest{Instrument: "EUR_USD"},
+		"zero units":         oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "0"},
+		"fractional units":   oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1.5"},
+		"too many units":     oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "100000001"},
+		"price precision":    oanda.LimitOrderRequest{Instrument: "EUR_USD", Units: "1", Price: "1.085125"},
+		"trailing stop":      oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1", OnFill: oanda.OnFill{TrailingStopLossOnFill: &oanda.TrailingStopLossDetails{Distance: "0.0001"}}},
+		"negative stop loss": oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1", OnFill: oanda.OnFill{StopLossOnFill: &oanda.StopLossDetails{Distance: "-0.001"}}},
+		"unparseable price":  oanda.StopOrderRequest{Instrument: "EUR_USD", Units: "1", Price: "abc"},
+	}
+	for name, order := range invalid {
+		if err := registry.ValidateOrder(order); err == nil {
+			t.Errorf("%s: ValidateOrder() should fail for %+v", name, order)
+		}
+	}
+
+	if err := eurUSD.ValidateOrder(oanda.TrailingStopLossOrderRequest{TradeID: "1", Distance: "2"}); err == nil {
+		t.Error("ValidateOrder() should fail for a trailing stop distance above the maximum")
+	}
+	if err := eurUSD.ValidateOrder(oanda.MarketOrderRequest{Instrument: "USD_JPY", Units: "1"}); err == nil {
+		t.Error("ValidateOrder() should fail for an order for another instrument")
+	}
+}
+
+func TestLoadInstruments(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/instruments" {
+			t.Errorf("path should be the account's instruments but is: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"instruments":[
+			{"name":"USD_JPY","pipLocation":-2,"displayPrecision":3},
+			{"name":"EUR_USD","pipLocation":-4,"displayPrecision":5,"minimumTradeSize":"1"}
+		],"lastTransactionID":"6"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+	cache := filepath.Join(t.TempDir(), "instruments.json")
+
+	for i := 0; i < 2; i++ {
+		registry, err := client.LoadInstruments(context.Background(), cache, time.Hour)
+		if err != nil {
+			t.Fatalf("LoadInstruments() produced an error: %v", err)
+		}
+		list := registry.List()
+		if len(list) != 2 || list[0].Name != "EUR_USD" || list[0].MinimumTradeSize != "1" {
+			t.Fatalf("LoadInstruments() should return both instruments sorted by name but returned: %+v", list)
+		}
+		if _, err := registry.Get("USD_JPY"); err != nil {
+			t.Errorf("Get() produced an error: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("the second load should use the cache but %d requests were sent", requests)
+	}
+}
@@ -0,0 +1,768 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Values for the timeInForce field of an order, see Oanda's [Order Definitions].
+//
+// [Order Definitions]: https://developer.oanda.com/rest-live-v20/order-df/
+const (
+	TimeInForceGTC = "GTC" // good until cancelled
+	TimeInForceGTD = "GTD" // good until GtdTime
+	TimeInForceGFD = "GFD" // good for day, cancelled at 5pm New York time
+	TimeInForceFOK = "FOK" // filled entirely or cancelled
+	TimeInForceIOC = "IOC" // filled as much as possible, the rest is cancelled
+)
+
+// Values for the positionFill field of an order.
+const (
+	PositionFillOpenOnly    = "OPEN_ONLY"
+	PositionFillReduceFirst = "REDUCE_FIRST"
+	PositionFillReduceOnly  = "REDUCE_ONLY"
+	PositionFillDefault     = "DEFAULT"
+)
+
+// Values for the triggerCondition field of an order.
+const (
+	TriggerConditionDefault = "DEFAULT"
+	TriggerConditionInverse = "INVERSE"
+	TriggerConditionBid     = "BID"
+	TriggerConditionAsk     = "ASK"
+	TriggerConditionMid     = "MID"
+)
+
+// OrderRequest is implemented by every order request type, see CreateOrder.
+type OrderRequest interface {
+	// OrderType returns the value of the order's "type" field, i.e. "MARKET".
+	OrderType() string
+}
+
+/*
+struct for a take profit order to create when an order is filled
+*/
+type TakeProfitDetails struct {
+	Price            string            `json:"price"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a stop loss order to create when an order is filled, set
+either Price or Distance
+*/
+type StopLossDetails struct {
+	Price            string            `json:"price,omitempty"`
+	Distance         string            `json:"distance,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a guaranteed stop loss order to create when an order is filled,
+set either Price or Distance
+*/
+type GuaranteedStopLossDetails struct {
+	Price            string            `json:"price,omitempty"`
+	Distance         string            `json:"distance,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a trailing stop loss order to create when an order is filled
+*/
+type TrailingStopLossDetails struct {
+	Distance         string            `json:"distance"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+embedded struct for the orders which open or reduce a trade when filled,
+holding the dependent orders to create and extensions for the trade
+*/
+type OnFill struct {
+	TakeProfitOnFill         *TakeProfitDetails         `json:"takeProfitOnFill,omitempty"`
+	StopLossOnFill           *StopLossDetails           `json:"stopLossOnFill,omitempty"`
+	GuaranteedStopLossOnFill *GuaranteedStopLossDetails `json:"guaranteedStopLossOnFill,omitempty"`
+	TrailingStopLossOnFill   *TrailingStopLossDetails   `json:"trailingStopLossOnFill,omitempty"`
+	TradeClientExtensions    *ClientExtensions          `json:"tradeClientExtensions,omitempty"`
+}
+
+/*
+struct for a MARKET order, filled immediately at the current price.
+Units are positive to buy and negative to sell.
+*/
+type MarketOrderRequest struct {
+	Instrument       string            `json:"instrument"`
+	Units            string            `json:"units"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	PriceBound       string            `json:"priceBound,omitempty"`
+	PositionFill     string            `json:"positionFill,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+	OnFill
+}
+
+/*
+struct for a LIMIT order, filled once the price is equal to or better than Price
+*/
+type LimitOrderRequest struct {
+	Instrument       string            `json:"instrument"`
+	Units            string            `json:"units"`
+	Price            string            `json:"price"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	PositionFill     string            `json:"positionFill,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+	OnFill
+}
+
+/*
+struct for a STOP order, filled once the price is equal to or worse than Price
+*/
+type StopOrderRequest struct {
+	Instrument       string            `json:"instrument"`
+	Units            string            `json:"units"`
+	Price            string            `json:"price"`
+	PriceBound       string            `json:"priceBound,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	PositionFill     string            `json:"positionFill,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+	OnFill
+}
+
+/*
+struct for a MARKET_IF_TOUCHED order, filled once the price crosses Price
+in the direction it was moving when the order was created
+*/
+type MarketIfTouchedOrderRequest struct {
+	Instrument       string            `json:"instrument"`
+	Units            string            `json:"units"`
+	Price            string            `json:"price"`
+	PriceBound       string            `json:"priceBound,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	PositionFill     string            `json:"positionFill,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+	OnFill
+}
+
+/*
+struct for a TAKE_PROFIT order, closes a trade once the price is equal to
+or better than Price. Set either TradeID or ClientTradeID.
+*/
+type TakeProfitOrderRequest struct {
+	TradeID          string            `json:"tradeID,omitempty"`
+	ClientTradeID    string            `json:"clientTradeID,omitempty"`
+	Price            string            `json:"price"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a STOP_LOSS order, closes a trade once the price is equal to
+or worse than Price, or Distance away from the trade's open price.
+Set either TradeID or ClientTradeID.
+*/
+type StopLossOrderRequest struct {
+	TradeID          string            `json:"tradeID,omitempty"`
+	ClientTradeID    string            `json:"clientTradeID,omitempty"`
+	Price            string            `json:"price,omitempty"`
+	Distance         string            `json:"distance,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a GUARANTEED_STOP_LOSS order, the same as a stop loss but
+filled at exactly Price no matter how far the market gaps.
+Set either TradeID or ClientTradeID.
+*/
+type GuaranteedStopLossOrderRequest struct {
+	TradeID          string            `json:"tradeID,omitempty"`
+	ClientTradeID    string            `json:"clientTradeID,omitempty"`
+	Price            string            `json:"price,omitempty"`
+	Distance         string            `json:"distance,omitempty"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for a TRAILING_STOP_LOSS order, a stop loss which follows the price
+at Distance as it moves in the trade's favour.
+Set either TradeID or ClientTradeID.
+*/
+type TrailingStopLossOrderRequest struct {
+	TradeID          string            `json:"tradeID,omitempty"`
+	ClientTradeID    string            `json:"clientTradeID,omitempty"`
+	Distance         string            `json:"distance"`
+	TimeInForce      string            `json:"timeInForce,omitempty"`
+	GtdTime          *Time             `json:"gtdTime,omitempty"`
+	TriggerCondition string            `json:"triggerCondition,omitempty"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+func (MarketOrderRequest) OrderType() string             { return "MARKET" }
+func (LimitOrderRequest) OrderType() string              { return "LIMIT" }
+func (StopOrderRequest) OrderType() string               { return "STOP" }
+func (MarketIfTouchedOrderRequest) OrderType() string    { return "MARKET_IF_TOUCHED" }
+func (TakeProfitOrderRequest) OrderType() string         { return "TAKE_PROFIT" }
+func (StopLossOrderRequest) OrderType() string           { return "STOP_LOSS" }
+func (GuaranteedStopLossOrderRequest) OrderType() string { return "GUARANTEED_STOP_LOSS" }
+func (TrailingStopLossOrderRequest) OrderType() string   { return "TRAILING_STOP_LOSS" }
+
+// marshalOrderRequest returns the json for an order request with its
+// "type" field added.
+func marshalOrderRequest(order OrderRequest) (json.RawMessage, error) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling json: %s", err.Error())
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("error marshaling json: %s", err.Error())
+	}
+	fields["type"], _ = json.Marshal(order.OrderType())
+
+	return json.Marshal(fields)
+}
+
+/*
+struct for unmarshalling the response from creating an order. Which
+transactions are set depends on what happened to the order, i.e. a market
+order has OrderCreateTransaction and either OrderFillTransaction or
+OrderCancelTransaction set.
+*/
+type OrderCreateResponse struct {
+	OrderCreateTransaction        Transaction             `json:"-"`
+	OrderFillTransaction          *OrderFillTransaction   `json:"orderFillTransaction,omitempty"`
+	OrderCancelTransaction        *OrderCancelTransaction `json:"orderCancelTransaction,omitempty"`
+	OrderReissueTransaction       Transaction             `json:"-"`
+	OrderReissueRejectTransaction Transaction             `json:"-"`
+	RelatedTransactionIDs         []string                `json:"relatedTransactionIDs"`
+	LastTransactionID             string                  `json:"lastTransactionID"`
+}
+
+func (r *OrderCreateResponse) UnmarshalJSON(data []byte) error {
+	type response OrderCreateResponse
+	var raw struct {
+		response
+		OrderCreateTransaction        json.RawMessage `json:"orderCreateTransaction"`
+		OrderReissueTransaction       json.RawMessage `json:"orderReissueTransaction"`
+		OrderReissueRejectTransaction json.RawMessage `json:"orderReissueRejectTransaction"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = OrderCreateResponse(raw.response)
+
+	var err error
+	if r.OrderCreateTransaction, err = unmarshalOptionalTransaction(raw.OrderCreateTransaction); err != nil {
+		return err
+	}
+	if r.OrderReissueTransaction, err = unmarshalOptionalTransaction(raw.OrderReissueTransaction); err != nil {
+		return err
+	}
+	if r.OrderReissueRejectTransaction, err = unmarshalOptionalTransaction(raw.OrderReissueRejectTransaction); err != nil {
+		return err
+	}
+	return nil
+}
+
+/*
+CreateOrder method will create an order for the client's account, order is
+one of MarketOrderRequest, LimitOrderRequest, StopOrderRequest,
+MarketIfTouchedOrderRequest, TakeProfitOrderRequest, StopLossOrderRequest,
+GuaranteedStopLossOrderRequest or TrailingStopLossOrderRequest.
+
+If the order is rejected an *APIError is returned, use its Transaction
+method to get the typed reject transaction.
+
+For more info go to Oandas documentation for [Order Endpoints].
+
+endpoint: /v3/accounts/{accountID}/orders
+
+[Order Endpoints]: https://developer.oanda.com/rest-live-v20/order-ep/
+*/
+func (c *Client) CreateOrder(ctx context.Context, order OrderRequest) (*OrderCreateResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	data, err := marshalOrderRequest(order)
+	if err != nil {
+		return nil, err
+	}
+
+	var response OrderCreateResponse
+	body := map[string]json.RawMessage{"order": data}
+	if err := c.send(ctx, "POST", c.accountPath("/orders"), body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Values for the state field of an order.
+const (
+	OrderStatePending   = "PENDING"
+	OrderStateFilled    = "FILLED"
+	OrderStateTriggered = "TRIGGERED"
+	OrderStateCancelled = "CANCELLED"
+	OrderStateAll       = "ALL" // only valid as a filter, see OrdersRequest
+)
+
+/*
+Order is implemented by every order type returned from Oanda's [Order Endpoints].
+Use a type switch to get the concrete type:
+
+	switch order := order.(type) {
+	case *oanda.LimitOrder:
+		fmt.Println("limit", order.Units, order.Instrument, "at", order.Price)
+	case *oanda.StopLossOrder:
+		fmt.Println("stop loss for trade", order.TradeID, "at", order.Price)
+	}
+
+Order types this package does not know about are decoded as *UnknownOrder.
+
+[Order Endpoints]: https://developer.oanda.com/rest-live-v20/order-ep/
+*/
+type Order interface {
+	// Base returns the fields shared by every order.
+	Base() *OrderBase
+}
+
+/*
+embedded struct with the fields shared by every Order, the fields after
+State are only set once the order has been filled, cancelled or replaced
+*/
+type OrderBase struct {
+	ID                      string   `json:"id"`
+	CreateTime              Time     `json:"createTime"`
+	Type                    string   `json:"type"`
+	State                   string   `json:"state"`
+	FillingTransactionID    string   `json:"fillingTransactionID,omitempty"`
+	FilledTime              *Time    `json:"filledTime,omitempty"`
+	TradeOpenedID           string   `json:"tradeOpenedID,omitempty"`
+	TradeReducedID          string   `json:"tradeReducedID,omitempty"`
+	TradeClosedIDs          []string `json:"tradeClosedIDs,omitempty"`
+	CancellingTransactionID string   `json:"cancellingTransactionID,omitempty"`
+	CancelledTime           *Time    `json:"cancelledTime,omitempty"`
+	ReplacesOrderID         string   `json:"replacesOrderID,omitempty"`
+	ReplacedByOrderID       string   `json:"replacedByOrderID,omitempty"`
+}
+
+func (o *OrderBase) Base() *OrderBase {
+	return o
+}
+
+/*
+struct for a MARKET order
+*/
+type MarketOrder struct {
+	OrderBase
+	MarketOrderRequest
+}
+
+/*
+struct for a LIMIT order
+*/
+type LimitOrder struct {
+	OrderBase
+	LimitOrderRequest
+}
+
+/*
+struct for a STOP order
+*/
+type StopOrder struct {
+	OrderBase
+	StopOrderRequest
+}
+
+/*
+struct for a MARKET_IF_TOUCHED order, InitialMarketPrice is the price
+when the order was created
+*/
+type MarketIfTouchedOrder struct {
+	OrderBase
+	MarketIfTouchedOrderRequest
+	InitialMarketPrice string `json:"initialMarketPrice,omitempty"`
+}
+
+/*
+struct for a TAKE_PROFIT order
+*/
+type TakeProfitOrder struct {
+	OrderBase
+	TakeProfitOrderRequest
+}
+
+/*
+struct for a STOP_LOSS order
+*/
+type StopLossOrder struct {
+	OrderBase
+	StopLossOrderRequest
+}
+
+/*
+struct for a GUARANTEED_STOP_LOSS order
+*/
+type GuaranteedStopLossOrder struct {
+	OrderBase
+	GuaranteedStopLossOrderRequest
+}
+
+/*
+struct for a TRAILING_STOP_LOSS order, TrailingStopValue is the price
+the order is currently set to trigger at
+*/
+type TrailingStopLossOrder struct {
+	OrderBase
+	TrailingStopLossOrderRequest
+	TrailingStopValue string `json:"trailingStopValue,omitempty"`
+}
+
+/*
+struct for any order type this package does not decode, Raw holds the
+complete json
+*/
+type UnknownOrder struct {
+	OrderBase
+	Raw json.RawMessage `json:"-"`
+}
+
+// orderTypes maps the "type" field of an order to a constructor
+// for its concrete type.
+var orderTypes = map[string]func() Order{
+	"MARKET":               func() Order { return &MarketOrder{} },
+	"LIMIT":                func() Order { return &LimitOrder{} },
+	"STOP":                 func() Order { return &StopOrder{} },
+	"MARKET_IF_TOUCHED":    func() Order { return &MarketIfTouchedOrder{} },
+	"TAKE_PROFIT":          func() Order { return &TakeProfitOrder{} },
+	"STOP_LOSS":            func() Order { return &StopLossOrder{} },
+	"GUARANTEED_STOP_LOSS": func() Order { return &GuaranteedStopLossOrder{} },
+	"TRAILING_STOP_LOSS":   func() Order { return &TrailingStopLossOrder{} },
+}
+
+// UnmarshalOrder decodes a single order into its concrete type based
+// on the "type" field.
+func UnmarshalOrder(data []byte) (Order, error) {
+	var base OrderBase
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	}
+
+	newOrder, ok := orderTypes[base.Type]
+	if !ok {
+		return &UnknownOrder{OrderBase: base, Raw: data}, nil
+	}
+
+	order := newOrder()
+	if err := json.Unmarshal(data, order); err != nil {
+		return nil, fmt.Errorf("error unmarshaling %s order: %s", base.Type, err.Error())
+	}
+	return order, nil
+}
+
+// unmarshalOrders decodes a list of orders into their concrete types.
+func unmarshalOrders(raw []json.RawMessage) ([]Order, error) {
+	orders := make([]Order, 0, len(raw))
+	for _, data := range raw {
+		order, err := UnmarshalOrder(data)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+/*
+struct for unmarshalling a list of orders from [Order Endpoints]
+
+endpoint: /v3/accounts/{accountID}/orders
+
+[Order Endpoints]: https://developer.oanda.com/rest-live-v20/order-ep/
+*/
+type OrderList struct {
+	Orders            []Order `json:"-"`
+	LastTransactionID string  `json:"lastTransactionID"`
+}
+
+func (l *OrderList) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Orders            []json.RawMessage `json:"orders"`
+		LastTransactionID string            `json:"lastTransactionID"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	orders, err := unmarshalOrders(raw.Orders)
+	if err != nil {
+		return err
+	}
+	l.Orders = orders
+	l.LastTransactionID = raw.LastTransactionID
+	return nil
+}
+
+/*
+struct for unmarshalling a single order from [Order Endpoints]
+
+endpoint: /v3/accounts/{accountID}/orders/{orderSpecifier}
+
+[Order Endpoints]: https://developer.oanda.com/rest-live-v20/order-ep/
+*/
+type OrderDetails struct {
+	Order             Order  `json:"-"`
+	LastTransactionID string `json:"lastTransactionID"`
+}
+
+func (d *OrderDetails) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Order             json.RawMessage `json:"order"`
+		LastTransactionID string          `json:"lastTransactionID"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	order, err := UnmarshalOrder(raw.Order)
+	if err != nil {
+		return err
+	}
+	d.Order = order
+	d.LastTransactionID = raw.LastTransactionID
+	return nil
+}
+
+// OrdersRequest holds the filters for GetOrders, zero values are not sent.
+type OrdersRequest struct {
+	// IDs of the orders to get.
+	IDs []string
+	// State of the orders to get, one of the OrderState constants.
+	// Defaults to OrderStatePending.
+	State string
+	// Instrument of the orders to get.
+	Instrument string
+	// Count is the maximum number of orders to return, at most 500.
+	// Defaults to 50.
+	Count int
+	// BeforeID only returns orders with an ID less than this one.
+	BeforeID string
+}
+
+// query returns the request as url query parameters.
+func (r *OrdersRequest) query() url.Values {
+	q := url.Values{}
+	if len(r.IDs) > 0 {
+		q.Add("ids", strings.Join(r.IDs, ","))
+	}
+	if r.State != "" {
+		q.Add("state", r.State)
+	}
+	if r.Instrument != "" {
+		q.Add("instrument", r.Instrument)
+	}
+	if r.Count > 0 {
+		q.Add("count", strconv.Itoa(r.Count))
+	}
+	if r.BeforeID != "" {
+		q.Add("beforeID", r.BeforeID)
+	}
+	return q
+}
+
+// ClientOrderSpecifier returns the order specifier for an order's client ID,
+// it can be used anywhere an order ID is accepted.
+func ClientOrderSpecifier(clientID string) string {
+	return "@" + clientID
+}
+
+// orderPath returns the path for an order specifier in the client's account.
+func (c *Client) orderPath(orderSpecifier, endpoint string) string {
+	return c.accountPath("/orders/" + url.PathEscape(orderSpecifier) + endpoint)
+}
+
+/*
+GetOrders method will return a list of orders for the client's account,
+a nil request returns the 50 most recent pending orders.
+
+endpoint: /v3/accounts/{accountID}/orders
+*/
+func (c *Client) GetOrders(ctx context.Context, req *OrdersRequest) (*OrderList, error) {
+	if req == nil {
+		req = &OrdersRequest{}
+	}
+
+	var list OrderList
+	if err := c.get(ctx, c.accountPath("/orders"), req.query(), &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetPendingOrders method will return every pending order for the client's account.
+
+endpoint: /v3/accounts/{accountID}/pendingOrders
+*/
+func (c *Client) GetPendingOrders(ctx context.Context) (*OrderList, error) {
+	var list OrderList
+	if err := c.get(ctx, c.accountPath("/pendingOrders"), nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetOrder method will return a single order for the client's account. The
+order specifier is either the order's ID or "@" followed by its client ID,
+see ClientOrderSpecifier.
+
+endpoint: /v3/accounts/{accountID}/orders/{orderSpecifier}
+*/
+func (c *Client) GetOrder(ctx context.Context, orderSpecifier string) (*OrderDetails, error) {
+	var details OrderDetails
+	if err := c.get(ctx, c.orderPath(orderSpecifier, ""), nil, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+/*
+struct for unmarshalling the response from replacing an order, the same as
+OrderCreateResponse with the cancel transactions for the replaced order
+*/
+type OrderReplaceResponse struct {
+	OrderCreateResponse
+	ReplacingOrderCancelTransaction *OrderCancelTransaction `json:"replacingOrderCancelTransaction,omitempty"`
+}
+
+func (r *OrderReplaceResponse) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.OrderCreateResponse); err != nil {
+		return err
+	}
+
+	var raw struct {
+		ReplacingOrderCancelTransaction *OrderCancelTransaction `json:"replacingOrderCancelTransaction"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.ReplacingOrderCancelTransaction = raw.ReplacingOrderCancelTransaction
+	return nil
+}
+
+/*
+ReplaceOrder method will cancel an order in the client's account and create
+the given order in its place. The cancel transaction is in OrderCancelTransaction.
+
+endpoint: /v3/accounts/{accountID}/orders/{orderSpecifier}
+*/
+func (c *Client) ReplaceOrder(ctx context.Context, orderSpecifier string, order OrderRequest) (*OrderReplaceResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	data, err := marshalOrderRequest(order)
+	if err != nil {
+		return nil, err
+	}
+
+	var response OrderReplaceResponse
+	body := map[string]json.RawMessage{"order": data}
+	if err := c.send(ctx, "PUT", c.orderPath(orderSpecifier, ""), body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+/*
+struct for unmarshalling the response from cancelling an order
+*/
+type OrderCancelResponse struct {
+	OrderCancelTransaction *OrderCancelTransaction `json:"orderCancelTransaction"`
+	RelatedTransactionIDs  []string                `json:"relatedTransactionIDs"`
+	LastTransactionID      string                  `json:"lastTransactionID"`
+}
+
+/*
+CancelOrder method will cancel a pending order in the client's account.
+
+endpoint: /v3/accounts/{accountID}/orders/{orderSpecifier}/cancel
+*/
+func (c *Client) CancelOrder(ctx context.Context, orderSpecifier string) (*OrderCancelResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	var response OrderCancelResponse
+	if err := c.send(ctx, "PUT", c.orderPath(orderSpecifier, "/cancel"), nil, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+/*
+struct for unmarshalling the response from updating an order's client extensions
+*/
+type OrderClientExtensionsResponse struct {
+	OrderClientExtensionsModifyTransaction *OrderClientExtensionsModifyTransaction `json:"orderClientExtensionsModifyTransaction"`
+	RelatedTransactionIDs                  []string                                `json:"relatedTransactionIDs"`
+	LastTransactionID                      string                                  `json:"lastTransactionID"`
+}
+
+/*
+SetOrderClientExtensions method will update the client extensions for an order
+in the client's account, and for the trade it creates when tradeExtensions is
+not nil. Do not set, modify, or delete these if your account is associated with MT4.
+
+endpoint: /v3/accounts/{accountID}/orders/{orderSpecifier}/clientExtensions
+*/
+func (c *Client) SetOrderClientExtensions(ctx context.Context, orderSpecifier string, extensions, tradeExtensions *ClientExtensions) (*OrderClientExtensionsResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		ClientExtensions      *ClientExtensions `json:"clientExtensions,omitempty"`
+		TradeClientExtensions *ClientExtensions `json:"tradeClientExtensions,omitempty"`
+	}{extensions, tradeExtensions}
+
+	var response OrderClientExtensionsResponse
+	if err := c.send(ctx, "PUT", c.orderPath(orderSpecifier, "/clientExtensions"), body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
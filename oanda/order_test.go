@@ -0,0 +1,238 @@
+package oanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestCreateOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v3/accounts/101-001-1234567-001/orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body struct {
+			Order map[string]any `json:"order"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %v", err)
+		}
+		if body.Order["type"] != "MARKET" || body.Order["units"] != "100" {
+			t.Errorf("order should be a MARKET order for 100 units but is: %v", body.Order)
+		}
+		if tp, ok := body.Order["takeProfitOnFill"].(map[string]any); !ok || tp["price"] != "1.1" {
+			t.Errorf("order should have a take profit on fill at 1.1 but has: %v", body.Order["takeProfitOnFill"])
+		}
+		if _, ok := body.Order["stopLossOnFill"]; ok {
+			t.Error("order should not include an empty stopLossOnFill")
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+	"orderCreateTransaction": {"id": "6", "type": "MARKET_ORDER", "instrument": "EUR_USD", "units": "100", "timeInForce": "FOK", "reason": "CLIENT_ORDER", "takeProfitOnFill": {"price": "1.1"}},
+	"orderFillTransaction": {"id": "7", "type": "ORDER_FILL", "orderID": "6", "instrument": "EUR_USD", "units": "100", "price": "1.08", "tradeOpened": {"tradeID": "7", "units": "100", "price": "1.08"}},
+	"relatedTransactionIDs": ["6", "7", "8"],
+	"lastTransactionID": "8"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	response, err := client.CreateOrder(context.Background(), oanda.MarketOrderRequest{
+		Instrument:  "EUR_USD",
+		Units:       "100",
+		TimeInForce: oanda.TimeInForceFOK,
+		OnFill: oanda.OnFill{
+			TakeProfitOnFill: &oanda.TakeProfitDetails{Price: "1.1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() produced an error: %v", err)
+	}
+
+	create, ok := response.OrderCreateTransaction.(*oanda.MarketOrderTransaction)
+	if !ok {
+		t.Fatalf("OrderCreateTransaction should be *oanda.MarketOrderTransaction but is: %T", response.OrderCreateTransaction)
+	}
+	if create.TakeProfitOnFill == nil || create.TakeProfitOnFill.Price != "1.1" {
+		t.Errorf("OrderCreateTransaction is missing its take profit: %+v", create)
+	}
+	if response.OrderFillTransaction == nil || response.OrderFillTransaction.TradeOpened.TradeID != "7" {
+		t.Errorf("OrderFillTransaction should open trade 7 but is: %+v", response.OrderFillTransaction)
+	}
+	if response.LastTransactionID != "8" {
+		t.Errorf("LastTransactionID should be 8 but is: %s", response.LastTransactionID)
+	}
+}
+
+func TestCreateOrderReject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{
+	"orderRejectTransaction": {"id": "9", "type": "LIMIT_ORDER_REJECT", "instrument": "EUR_USD", "units": "100", "price": "-1", "rejectReason": "PRICE_INVALID"},
+	"lastTransactionID": "9",
+	"errorCode": "PRICE_INVALID",
+	"errorMessage": "The price specified is invalid"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	_, err := client.CreateOrder(context.Background(), oanda.LimitOrderRequest{
+		Instrument: "EUR_USD",
+		Units:      "100",
+		Price:      "-1",
+	})
+
+	var apiErr *oanda.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("CreateOrder() should return *oanda.APIError but returned: %v", err)
+	}
+	tx, err := apiErr.Transaction()
+	if err != nil {
+		t.Fatalf("Transaction() produced an error: %v", err)
+	}
+	reject, ok := tx.(*oanda.LimitOrderRejectTransaction)
+	if !ok || reject.RejectReason != "PRICE_INVALID" || reject.Price != "-1" {
+		t.Fatalf("Transaction() should return the *oanda.LimitOrderRejectTransaction but returned: %+v", tx)
+	}
+}
+
+func TestCreateOrderLiveTradingDisabled(t *testing.T) {
+	client := oanda.NewClient(oanda.WithEnvironment(oanda.Live))
+
+	_, err := client.CreateOrder(context.Background(), oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1"})
+	if !errors.Is(err, oanda.ErrLiveTradingDisabled) {
+		t.Fatalf("CreateOrder() should fail with oanda.ErrLiveTradingDisabled but returned: %v", err)
+	}
+}
+
+func TestCreateOrderLiveBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"https://api-fxtrade.oanda.com/", "https://API-FXTRADE.oanda.com", "https://api-fxtrade.oanda.com:443"} {
+		client := oanda.NewClient(oanda.WithBaseURL(baseURL))
+
+		_, err := client.CreateOrder(context.Background(), oanda.MarketOrderRequest{Instrument: "EUR_USD", Units: "1"})
+		if !errors.Is(err, oanda.ErrLiveTradingDisabled) {
+			t.Errorf("CreateOrder() to %s should fail with oanda.ErrLiveTradingDisabled but returned: %v", baseURL, err)
+		}
+	}
+}
+
+func TestGetOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "count=2&instrument=EUR_USD&state=ALL"; r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"orders":[
+	{"id":"10","type":"LIMIT","state":"PENDING","instrument":"EUR_USD","units":"100","price":"1.05","clientExtensions":{"id":"my-limit"}},
+	{"id":"9","type":"TRAILING_STOP_LOSS","state":"PENDING","tradeID":"7","distance":"0.005","trailingStopValue":"1.075"},
+	{"id":"8","type":"FIXED_PRICE","state":"FILLED"}
+],"lastTransactionID":"10"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+	list, err := client.GetOrders(context.Background(), &oanda.OrdersRequest{
+		State:      oanda.OrderStateAll,
+		Instrument: "EUR_USD",
+		Count:      2,
+	})
+	if err != nil {
+		t.Fatalf("GetOrders() produced an error: %v", err)
+	}
+	if len(list.Orders) != 3 {
+		t.Fatalf("GetOrders() should return 3 orders but returned: %d", len(list.Orders))
+	}
+
+	limit, ok := list.Orders[0].(*oanda.LimitOrder)
+	if !ok || limit.Price != "1.05" || limit.ClientExtensions.ID != "my-limit" || limit.State != oanda.OrderStatePending {
+		t.Errorf("first order should be the *oanda.LimitOrder but is: %+v", list.Orders[0])
+	}
+	trailing, ok := list.Orders[1].(*oanda.TrailingStopLossOrder)
+	if !ok || trailing.TradeID != "7" || trailing.TrailingStopValue != "1.075" {
+		t.Errorf("second order should be the *oanda.TrailingStopLossOrder but is: %+v", list.Orders[1])
+	}
+	if unknown, ok := list.Orders[2].(*oanda.UnknownOrder); !ok || unknown.ID != "8" {
+		t.Errorf("third order should be an *oanda.UnknownOrder but is: %+v", list.Orders[2])
+	}
+}
+
+func TestOrderTimesJSON(t *testing.T) {
+	order, err := oanda.UnmarshalOrder([]byte(`{"id":"10","type":"LIMIT","state":"PENDING","createTime":"2024-07-19T21:00:00Z","instrument":"EUR_USD","units":"100","price":"1.05"}`))
+	if err != nil {
+		t.Fatalf("UnmarshalOrder() produced an error: %v", err)
+	}
+	data, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if strings.Contains(string(data), "filledTime") || strings.Contains(string(data), "cancelledTime") {
+		t.Errorf("a pending order should not have filledTime or cancelledTime but json is: %s", data)
+	}
+
+	order, err = oanda.UnmarshalOrder([]byte(`{"id":"11","type":"LIMIT","state":"FILLED","filledTime":"1721422800.000000000"}`))
+	if err != nil {
+		t.Fatalf("UnmarshalOrder() produced an error: %v", err)
+	}
+	if filled := order.Base().FilledTime; filled == nil || filled.Unix() != 1721422800 {
+		t.Errorf("FilledTime should be decoded but is: %v", filled)
+	}
+}
+
+func TestOrderSpecifier(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"order":{"id":"10","type":"STOP","state":"PENDING","price":"1.1"},"lastTransactionID":"10"}`)
+		default:
+			fmt.Fprint(w, `{"orderCancelTransaction":{"id":"11","type":"ORDER_CANCEL","orderID":"10","reason":"CLIENT_REQUEST"},"lastTransactionID":"11"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	details, err := client.GetOrder(context.Background(), oanda.ClientOrderSpecifier("my-stop"))
+	if err != nil {
+		t.Fatalf("GetOrder() produced an error: %v", err)
+	}
+	if stop, ok := details.Order.(*oanda.StopOrder); !ok || stop.Price != "1.1" {
+		t.Errorf("GetOrder() should return the *oanda.StopOrder but returned: %+v", details.Order)
+	}
+
+	cancelled, err := client.CancelOrder(context.Background(), "10")
+	if err != nil {
+		t.Fatalf("CancelOrder() produced an error: %v", err)
+	}
+	if cancelled.OrderCancelTransaction.OrderID != "10" {
+		t.Errorf("CancelOrder() should cancel order 10 but cancelled: %+v", cancelled.OrderCancelTransaction)
+	}
+
+	want := []string{
+		"GET /v3/accounts/101-001-1234567-001/orders/@my-stop",
+		"PUT /v3/accounts/101-001-1234567-001/orders/10/cancel",
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d should be %q but is: %q", i, want[i], paths[i])
+		}
+	}
+}
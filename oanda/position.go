@@ -0,0 +1,127 @@
+package oanda
+
+import (
+	"context"
+	"net/url"
+)
+
+/*
+struct for unmarshalling a list of positions from [Position Endpoints]
+
+endpoint: /v3/accounts/{accountID}/positions
+
+[Position Endpoints]: https://developer.oanda.com/rest-live-v20/position-ep/
+*/
+type PositionList struct {
+	Positions         []PositionsID `json:"positions"`
+	LastTransactionID string        `json:"lastTransactionID"`
+}
+
+/*
+struct for unmarshalling a single instrument's position from [Position Endpoints]
+
+endpoint: /v3/accounts/{accountID}/positions/{instrument}
+
+[Position Endpoints]: https://developer.oanda.com/rest-live-v20/position-ep/
+*/
+type PositionDetails struct {
+	Position          PositionsID `json:"position"`
+	LastTransactionID string      `json:"lastTransactionID"`
+}
+
+// positionPath returns the path for an instrument's position in the client's account.
+func (c *Client) positionPath(instrument, endpoint string) string {
+	return c.accountPath("/positions/" + url.PathEscape(instrument) + endpoint)
+}
+
+/*
+GetPositions method will return every position for the client's account,
+including every instrument which has had a position during the lifetime
+of the account.
+
+endpoint: /v3/accounts/{accountID}/positions
+*/
+func (c *Client) GetPositions(ctx context.Context) (*PositionList, error) {
+	var list PositionList
+	if err := c.get(ctx, c.accountPath("/positions"), nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetOpenPositions method will return the positions in the client's account
+which currently have a trade open.
+
+endpoint: /v3/accounts/{accountID}/openPositions
+*/
+func (c *Client) GetOpenPositions(ctx context.Context) (*PositionList, error) {
+	var list PositionList
+	if err := c.get(ctx, c.accountPath("/openPositions"), nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetPosition method will return the position for a single instrument in the
+client's account, the position may be open or not.
+
+endpoint: /v3/accounts/{accountID}/positions/{instrument}
+*/
+func (c *Client) GetPosition(ctx context.Context, instrument string) (*PositionDetails, error) {
+	var details PositionDetails
+	if err := c.get(ctx, c.positionPath(instrument, ""), nil, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+/*
+PositionCloseRequest holds the sides of a position to close with ClosePosition.
+Units are either "ALL" or a (positive) number of units, a side with empty
+units is not closed.
+*/
+type PositionCloseRequest struct {
+	LongUnits             string            `json:"longUnits,omitempty"`
+	LongClientExtensions  *ClientExtensions `json:"longClientExtensions,omitempty"`
+	ShortUnits            string            `json:"shortUnits,omitempty"`
+	ShortClientExtensions *ClientExtensions `json:"shortClientExtensions,omitempty"`
+}
+
+/*
+struct for unmarshalling the response from closing a position, the market
+order created for each side and the transactions which filled or cancelled it
+*/
+type PositionCloseResponse struct {
+	LongOrderCreateTransaction  *MarketOrderTransaction `json:"longOrderCreateTransaction,omitempty"`
+	LongOrderFillTransaction    *OrderFillTransaction   `json:"longOrderFillTransaction,omitempty"`
+	LongOrderCancelTransaction  *OrderCancelTransaction `json:"longOrderCancelTransaction,omitempty"`
+	ShortOrderCreateTransaction *MarketOrderTransaction `json:"shortOrderCreateTransaction,omitempty"`
+	ShortOrderFillTransaction   *OrderFillTransaction   `json:"shortOrderFillTransaction,omitempty"`
+	ShortOrderCancelTransaction *OrderCancelTransaction `json:"shortOrderCancelTransaction,omitempty"`
+	RelatedTransactionIDs       []string                `json:"relatedTransactionIDs"`
+	LastTransactionID           string                  `json:"lastTransactionID"`
+}
+
+/*
+ClosePosition method will close out the long and/or short side of the
+client's position in an instrument.
+
+endpoint: /v3/accounts/{accountID}/positions/{instrument}/close
+*/
+func (c *Client) ClosePosition(ctx context.Context, instrument string, req PositionCloseRequest) (*PositionCloseResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	var response PositionCloseResponse
+	if err := c.send(ctx, "PUT", c.positionPath(instrument, "/close"), req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
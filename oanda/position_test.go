@@ -0,0 +1,105 @@
+package oanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestGetPosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/positions/EUR_USD" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"position":{
+	"instrument": "EUR_USD", "pl": "1.5000", "unrealizedPL": "0.2500", "marginUsed": "4.3200",
+	"long": {"units": "200", "averagePrice": "1.08", "tradeIDs": ["7", "9"], "pl": "1.5000", "unrealizedPL": "0.2500", "marginUsed": "4.3200"},
+	"short": {"units": "0", "pl": "0.0000", "unrealizedPL": "0.0000"}
+},"lastTransactionID":"9"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	details, err := client.GetPosition(context.Background(), "EUR_USD")
+	if err != nil {
+		t.Fatalf("GetPosition() produced an error: %v", err)
+	}
+
+	position := details.Position
+	if position.MarginUsed != "4.3200" {
+		t.Errorf("MarginUsed should be 4.3200 but is: %s", position.MarginUsed)
+	}
+	if position.Long.AveragePrice != "1.08" || len(position.Long.TradeIDs) != 2 || position.Long.MarginUsed != "4.3200" {
+		t.Errorf("long side was not decoded: %+v", position.Long)
+	}
+	if position.Short.Units != "0" || len(position.Short.TradeIDs) != 0 {
+		t.Errorf("short side should be empty but is: %+v", position.Short)
+	}
+}
+
+func TestClosePosition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/v3/accounts/101-001-1234567-001/positions/EUR_USD/close" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %v", err)
+		}
+		if body["longUnits"] != "ALL" {
+			t.Errorf("longUnits should be ALL but is: %v", body["longUnits"])
+		}
+		if _, ok := body["shortUnits"]; ok {
+			t.Error("shortUnits should be left out when the short side is not closed")
+		}
+
+		fmt.Fprint(w, `{
+	"longOrderCreateTransaction": {"id": "10", "type": "MARKET_ORDER", "instrument": "EUR_USD", "units": "-200", "reason": "POSITION_CLOSEOUT", "longPositionCloseout": {"instrument": "EUR_USD", "units": "ALL"}},
+	"longOrderFillTransaction": {"id": "11", "type": "ORDER_FILL", "orderID": "10", "units": "-200", "pl": "1.7500"},
+	"relatedTransactionIDs": ["10", "11"],
+	"lastTransactionID": "11"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	response, err := client.ClosePosition(context.Background(), "EUR_USD", oanda.PositionCloseRequest{LongUnits: "ALL"})
+	if err != nil {
+		t.Fatalf("ClosePosition() produced an error: %v", err)
+	}
+
+	create := response.LongOrderCreateTransaction
+	if create == nil || create.LongPositionCloseout == nil || create.LongPositionCloseout.Units != "ALL" {
+		t.Errorf("LongOrderCreateTransaction should close out the long side but is: %+v", create)
+	}
+	if fill := response.LongOrderFillTransaction; fill == nil || fill.PL != "1.7500" {
+		t.Errorf("LongOrderFillTransaction should have a PL of 1.7500 but is: %+v", fill)
+	}
+	if response.ShortOrderCreateTransaction != nil {
+		t.Errorf("ShortOrderCreateTransaction should be nil but is: %+v", response.ShortOrderCreateTransaction)
+	}
+}
+
+func TestClosePositionLiveTradingDisabled(t *testing.T) {
+	client := oanda.NewClient(oanda.WithEnvironment(oanda.Live))
+
+	_, err := client.ClosePosition(context.Background(), "EUR_USD", oanda.PositionCloseRequest{ShortUnits: "ALL"})
+	if !errors.Is(err, oanda.ErrLiveTradingDisabled) {
+		t.Fatalf("ClosePosition() should fail with oanda.ErrLiveTradingDisabled but returned: %v", err)
+	}
+}
@@ -0,0 +1,303 @@
+package oanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+struct for a level of a price's order book, the price available for up to
+Liquidity units
+*/
+type PriceBucket struct {
+	Price     string `json:"price"`
+	Liquidity int64  `json:"liquidity"`
+}
+
+// ErrInsufficientLiquidity is returned by VWAP when the price buckets do not
+// have enough liquidity to fill the order size.
+var ErrInsufficientLiquidity = errors.New("insufficient liquidity")
+
+// PriceBuckets are the levels of a price's order book from best to worst.
+type PriceBuckets []PriceBucket
+
+/*
+VWAP method returns the volume weighted average price for filling units
+(which must be positive) by walking the buckets from best to worst, the
+expected fill price for a market order of that size. It is rounded half away
+from zero to 4 more decimal places than the most precise bucket price used.
+
+ErrInsufficientLiquidity is returned when the buckets do not hold enough
+liquidity.
+*/
+func (b PriceBuckets) VWAP(units Decimal) (Decimal, error) {
+	if units.Sign() <= 0 {
+		return Decimal{}, fmt.Errorf("error calculating vwap: units must be positive but are %s", units)
+	}
+
+	remaining := units
+	var cost Decimal
+	places := 0
+	for _, bucket := range b {
+		price, err := bucket.Decimal()
+		if err != nil {
+			return Decimal{}, fmt.Errorf("error parsing bucket price: %s", err.Error())
+		}
+		places = max(places, price.Scale())
+
+		fill := NewDecimal(bucket.Liquidity, 0)
+		if fill.Cmp(remaining) > 0 {
+			fill = remaining
+		}
+		cost = cost.Add(fill.Mul(price))
+		remaining = remaining.Sub(fill)
+		if remaining.Sign() <= 0 {
+			return cost.Div(units, places+4), nil
+		}
+	}
+	return Decimal{}, fmt.Errorf("error calculating vwap: %w for %s units, %s short", ErrInsufficientLiquidity, units, remaining)
+}
+
+// vwap walks asks for a buy (positive units) or bids for a sell
+// (negative units).
+func vwap(bids, asks PriceBuckets, units Decimal) (Decimal, error) {
+	if units.Sign() < 0 {
+		return bids.VWAP(units.Neg())
+	}
+	return asks.VWAP(units)
+}
+
+/*
+struct for the factors used to convert quantities in an instrument's quote
+currency into the account's home currency, PositiveUnits for long positions
+and NegativeUnits for short positions
+*/
+type QuoteHomeConversionFactors struct {
+	PositiveUnits string `json:"positiveUnits"`
+	NegativeUnits string `json:"negativeUnits"`
+}
+
+/*
+struct for the number of units which can be bought (Long) or sold (Short)
+*/
+type UnitsAvailableDetails struct {
+	Long  string `json:"long"`
+	Short string `json:"short"`
+}
+
+/*
+struct for the number of units available to trade in an instrument for each
+position fill option (see PositionFill)
+*/
+type UnitsAvailable struct {
+	Default     UnitsAvailableDetails `json:"default"`
+	ReduceFirst UnitsAvailableDetails `json:"reduceFirst"`
+	ReduceOnly  UnitsAvailableDetails `json:"reduceOnly"`
+	OpenOnly    UnitsAvailableDetails `json:"openOnly"`
+}
+
+/*
+struct for unmarshalling a price from Oanda's [Pricing Endpoints], returned
+by GetPricing and sent by the price streams as a Stream. Bids and Asks hold
+every level of liquidity from best to worst, use VWAP to get the expected
+fill price for an order size.
+
+[Pricing Endpoints]: https://developer.oanda.com/rest-live-v20/pricing-ep/
+*/
+type ClientPrice struct {
+	Type                       string                      `json:"type"`
+	Instrument                 string                      `json:"instrument"`
+	Time                       Time                        `json:"time"`
+	Status                     string                      `json:"status,omitempty"`
+	Tradeable                  bool                        `json:"tradeable"`
+	Bids                       PriceBuckets                `json:"bids"`
+	Asks                       PriceBuckets                `json:"asks"`
+	CloseOutBid                string                      `json:"closeoutBid"`
+	CloseOutAsk                string                      `json:"closeoutAsk"`
+	QuoteHomeConversionFactors *QuoteHomeConversionFactors `json:"quoteHomeConversionFactors,omitempty"`
+	UnitsAvailable             *UnitsAvailable             `json:"unitsAvailable,omitempty"`
+}
+
+// VWAP returns the expected fill price for an order of units, walking the
+// asks for a buy (positive units) or the bids for a sell (negative units).
+func (p ClientPrice) VWAP(units Decimal) (Decimal, error) {
+	return vwap(p.Bids, p.Asks, units)
+}
+
+/*
+struct for the factors used to convert amounts in Currency into the
+account's home currency
+*/
+type HomeConversions struct {
+	Currency      string `json:"currency"`
+	AccountGain   string `json:"accountGain"`
+	AccountLoss   string `json:"accountLoss"`
+	PositionValue string `json:"positionValue"`
+}
+
+/*
+struct for unmarshalling a pricing snapshot from Oanda's [Pricing Endpoints]
+
+endpoint: /v3/accounts/{accountID}/pricing
+
+[Pricing Endpoints]: https://developer.oanda.com/rest-live-v20/pricing-ep/
+*/
+type PricingSnapshot struct {
+	Prices          []ClientPrice     `json:"prices"`
+	HomeConversions []HomeConversions `json:"homeConversions,omitempty"`
+	Time            Time              `json:"time"`
+}
+
+// PricingRequest holds the query parameters for GetPricing.
+type PricingRequest struct {
+	// Instruments to get prices for, at least one is required.
+	Instruments []string
+	// Since only returns prices which changed after this time.
+	Since time.Time
+	// IncludeHomeConversions includes the home currency conversion factors.
+	IncludeHomeConversions bool
+}
+
+// query returns the request as url query parameters, with times in format.
+func (r *PricingRequest) query(format DatetimeFormat) url.Values {
+	q := url.Values{}
+	q.Add("instruments", strings.Join(r.Instruments, ","))
+	if !r.Since.IsZero() {
+		q.Add("since", format.format(r.Since))
+	}
+	if r.IncludeHomeConversions {
+		q.Add("includeHomeConversions", "true")
+	}
+	return q
+}
+
+/*
+GetPricing method will return a snapshot of the current prices for a list of
+instruments in the client's account, including the units available to trade.
+
+endpoint: /v3/accounts/{accountID}/pricing
+*/
+func (c *Client) GetPricing(ctx context.Context, req PricingRequest) (*PricingSnapshot, error) {
+	if len(req.Instruments) == 0 {
+		return nil, fmt.Errorf("invalid pricing request: at least one instrument is required")
+	}
+
+	var snapshot PricingSnapshot
+	if err := c.get(ctx, c.accountPath("/pricing"), req.query(c.datetimeFormat), &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+/*
+CandleSpecification selects the candles to get from GetLatestCandles, it is
+sent as "instrument:granularity:price", i.e. "EUR_USD:S10:BM".
+*/
+type CandleSpecification struct {
+	Instrument string
+	// Granularity of the candlesticks, i.e. GranularityS10.
+	Granularity Granularity
+	// Price components, any combination of "M", "B" and "A".
+	Price string
+}
+
+func (s CandleSpecification) String() string {
+	return s.Instrument + ":" + string(s.Granularity) + ":" + s.Price
+}
+
+// LatestCandlesRequest holds the query parameters for GetLatestCandles,
+// zero values are not sent.
+type LatestCandlesRequest struct {
+	// Specifications of the candles to get, at least one is required.
+	Specifications []CandleSpecification
+	// Units to use when calculating the candles' prices, defaults to 1.
+	Units string
+	// Smooth uses the previous candle's close price as its open price.
+	Smooth bool
+	// DailyAlignment is the hour of day (0 to 23) used for granularities
+	// with daily alignment. Defaults to 17.
+	DailyAlignment *int
+	// AlignmentTimezone is the timezone DailyAlignment is in,
+	// i.e. "America/New_York" which is the default.
+	AlignmentTimezone string
+	// WeeklyAlignment is the day of the week used for granularities
+	// with weekly alignment, i.e. "Friday" which is the default.
+	WeeklyAlignment string
+}
+
+// Validate checks for parameters which Oanda would reject.
+func (r *LatestCandlesRequest) Validate() error {
+	if len(r.Specifications) == 0 {
+		return fmt.Errorf("invalid latest candles request: at least one candle specification is required")
+	}
+	for _, spec := range r.Specifications {
+		if spec.Instrument == "" || spec.Granularity == "" || spec.Price == "" {
+			return fmt.Errorf("invalid latest candles request: candle specification %q is incomplete", spec)
+		}
+		if err := (&CandlesRequest{Price: spec.Price, Granularity: spec.Granularity}).Validate(); err != nil {
+			return fmt.Errorf("invalid latest candles request: %q: %w", spec, err)
+		}
+	}
+	if r.DailyAlignment != nil && (*r.DailyAlignment < 0 || *r.DailyAlignment > 23) {
+		return fmt.Errorf("invalid latest candles request: dailyAlignment must be between 0 and 23 but is %d", *r.DailyAlignment)
+	}
+	if r.WeeklyAlignment != "" && !weekdays[r.WeeklyAlignment] {
+		return fmt.Errorf("invalid latest candles request: weeklyAlignment %q is not a day of the week", r.WeeklyAlignment)
+	}
+	return nil
+}
+
+// query returns the request as url query parameters.
+func (r *LatestCandlesRequest) query() url.Values {
+	specs := make([]string, len(r.Specifications))
+	for i, spec := range r.Specifications {
+		specs[i] = spec.String()
+	}
+
+	q := url.Values{}
+	q.Add("candleSpecifications", strings.Join(specs, ","))
+	if r.Units != "" {
+		q.Add("units", r.Units)
+	}
+	if r.Smooth {
+		q.Add("smooth", "true")
+	}
+	if r.DailyAlignment != nil {
+		q.Add("dailyAlignment", strconv.Itoa(*r.DailyAlignment))
+	}
+	if r.AlignmentTimezone != "" {
+		q.Add("alignmentTimezone", r.AlignmentTimezone)
+	}
+	if r.WeeklyAlignment != "" {
+		q.Add("weeklyAlignment", r.WeeklyAlignment)
+	}
+	return q
+}
+
+/*
+GetLatestCandles method will return the current incomplete candle and the
+most recently completed candles for each candle specification, in the same
+order as the specifications.
+
+endpoint: /v3/accounts/{accountID}/candles/latest
+*/
+func (c *Client) GetLatestCandles(ctx context.Context, req LatestCandlesRequest) ([]Metadata, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		LatestCandles []Metadata `json:"latestCandles"`
+	}
+	if err := c.get(ctx, c.accountPath("/candles/latest"), req.query(), &response); err != nil {
+		return nil, err
+	}
+
+	return response.LatestCandles, nil
+}
@@ -0,0 +1,113 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestGetPricing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/pricing" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if want := "includeHomeConversions=true&instruments=EUR_USD%2CUSD_JPY"; r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"time":"2024-01-02T10:00:00Z","prices":[{
+	"type": "PRICE", "instrument": "EUR_USD", "time": "2024-01-02T10:00:00Z", "tradeable": true,
+	"bids": [{"price": "1.08500", "liquidity": 1000000}, {"price": "1.08490", "liquidity": 5000000}],
+	"asks": [{"price": "1.08510", "liquidity": 1000000}, {"price": "1.08520", "liquidity": 5000000}],
+	"closeoutBid": "1.08490", "closeoutAsk": "1.08520",
+	"quoteHomeConversionFactors": {"positiveUnits": "1.00000", "negativeUnits": "1.00000"},
+	"unitsAvailable": {"default": {"long": "4600", "short": "4600"}}
+}],"homeConversions":[{"currency":"JPY","accountGain":"0.0068","accountLoss":"0.0069","positionValue":"0.0068"}]}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	snapshot, err := client.GetPricing(context.Background(), oanda.PricingRequest{
+		Instruments:            []string{"EUR_USD", "USD_JPY"},
+		IncludeHomeConversions: true,
+	})
+	if err != nil {
+		t.Fatalf("GetPricing() produced an error: %v", err)
+	}
+	if len(snapshot.Prices) != 1 || len(snapshot.HomeConversions) != 1 {
+		t.Fatalf("GetPricing() should return 1 price and 1 home conversion but returned: %+v", snapshot)
+	}
+
+	price := snapshot.Prices[0]
+	if len(price.Bids) != 2 || price.Bids[1].Liquidity != 5000000 || price.Asks[1].Price != "1.08520" {
+		t.Errorf("every price bucket should be decoded but bids are %+v and asks are %+v", price.Bids, price.Asks)
+	}
+	if price.CloseOutBid != "1.08490" || price.QuoteHomeConversionFactors.PositiveUnits != "1.00000" {
+		t.Errorf("closeout and conversion factors were not decoded: %+v", price)
+	}
+	if price.UnitsAvailable.Default.Long != "4600" {
+		t.Errorf("units available should be 4600 but is: %+v", price.UnitsAvailable)
+	}
+}
+
+func TestGetLatestCandles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/candles/latest" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if want := "EUR_USD:S10:BM,USD_JPY:H1:M"; r.URL.Query().Get("candleSpecifications") != want {
+			t.Errorf("candleSpecifications should be %q but is: %q", want, r.URL.Query().Get("candleSpecifications"))
+		}
+		fmt.Fprint(w, `{"latestCandles":[
+	{"instrument":"EUR_USD","granularity":"S10","candles":[{"complete":false,"volume":3,"time":"2024-01-02T10:00:00Z","bid":{"c":"1.085"},"mid":{"c":"1.0851"}}]},
+	{"instrument":"USD_JPY","granularity":"H1","candles":[]}
+]}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	latest, err := client.GetLatestCandles(context.Background(), oanda.LatestCandlesRequest{
+		Specifications: []oanda.CandleSpecification{
+			{Instrument: "EUR_USD", Granularity: "S10", Price: "BM"},
+			{Instrument: "USD_JPY", Granularity: "H1", Price: "M"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("GetLatestCandles() produced an error: %v", err)
+	}
+	if len(latest) != 2 || latest[0].Granularity != "S10" || len(latest[0].Candles) != 1 {
+		t.Fatalf("GetLatestCandles() should return candles for both specifications but returned: %+v", latest)
+	}
+	if candle := latest[0].Candles[0]; candle.Bid.C != "1.085" || candle.Mid.C != "1.0851" {
+		t.Errorf("candle was not decoded: %+v", candle)
+	}
+}
+
+func TestLatestCandlesRequestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  oanda.LatestCandlesRequest
+	}{
+		{"no specifications", oanda.LatestCandlesRequest{}},
+		{"incomplete specification", oanda.LatestCandlesRequest{Specifications: []oanda.CandleSpecification{{Instrument: "EUR_USD", Price: "M"}}}},
+		{"invalid price", oanda.LatestCandlesRequest{Specifications: []oanda.CandleSpecification{{Instrument: "EUR_USD", Granularity: "S5", Price: "X"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); err == nil {
+				t.Error("Validate() should return an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package oanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// ErrStreamStale is the reason given in a StreamStateEvent when no message,
+// not even a heartbeat, was received within ReconnectOptions.HeartbeatTimeout.
+var ErrStreamStale = errors.New("no heartbeat received from stream")
+
+// StreamState is the state of a reconnecting stream's connection.
+type StreamState int
+
+const (
+	// StreamConnected is sent every time Oanda accepts a connection.
+	StreamConnected StreamState = iota
+	// StreamStale is sent when the heartbeat timeout is exceeded, the
+	// connection is closed and StreamReconnecting follows.
+	StreamStale
+	// StreamReconnecting is sent before waiting to reconnect.
+	StreamReconnecting
+)
+
+func (s StreamState) String() string {
+	switch s {
+	case StreamConnected:
+		return "connected"
+	case StreamStale:
+		return "stale"
+	case StreamReconnecting:
+		return "reconnecting"
+	default:
+		return fmt.Sprintf("StreamState(%d)", int(s))
+	}
+}
+
+// StreamStateEvent is sent on a reconnecting stream's channel whenever the
+// state of its connection changes.
+type StreamStateEvent struct {
+	State StreamState
+	// Err is why the connection was lost, nil for StreamConnected.
+	Err error
+	// Attempt counts the reconnects since the last successful connection.
+	Attempt int
+	// Delay is how long until the next reconnect, only set for StreamReconnecting.
+	Delay time.Duration
+}
+
+func (StreamStateEvent) priceMessage() {}
+
+// ReconnectOptions configures how a stream detects a dead connection and
+// reconnects. The zero value uses the defaults listed for each field.
+type ReconnectOptions struct {
+	// HeartbeatTimeout is how long to wait for any message before the
+	// connection is considered stale. Oanda sends a heartbeat every
+	// 5 seconds. Defaults to 10 seconds.
+	HeartbeatTimeout time.Duration
+	// MinBackoff is the delay before the first reconnect. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between reconnects. Defaults to 1 minute.
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of reconnects without a successful
+	// connection before giving up, zero means never give up.
+	MaxAttempts int
+}
+
+// withDefaults returns a copy of opts with zero values replaced by defaults.
+func (opts *ReconnectOptions) withDefaults() ReconnectOptions {
+	var o ReconnectOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.HeartbeatTimeout <= 0 {
+		o.HeartbeatTimeout = 10 * time.Second
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = o.MinBackoff
+	}
+	return o
+}
+
+// backoff returns the jittered exponential delay before reconnect attempt,
+// somewhere between half and all of MinBackoff * 2^(attempt-1).
+func (opts ReconnectOptions) backoff(attempt int) time.Duration {
+	delay := opts.MinBackoff
+	for i := 1; i < attempt && delay < opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > opts.MaxBackoff {
+		delay = opts.MaxBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryable reports whether a stream which failed with err should reconnect,
+// requests Oanda rejected outright, or which could not be sent, will fail again.
+func retryable(err error) bool {
+	if errors.Is(err, ErrStreamURLUnset) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// watchdog closes a stream's connection when nothing is received from it
+// within the heartbeat timeout.
+type watchdog struct {
+	timer     *time.Timer
+	timeout   time.Duration
+	connected func() error
+}
+
+// pause calls fn with the watchdog stopped, so time spent blocked in fn
+// does not count towards the heartbeat timeout, and restarts it once fn
+// returns.
+func (w *watchdog) pause(fn func() error) error {
+	if !w.timer.Stop() {
+		// the watchdog already fired, the connection is being closed
+		return ErrStreamStale
+	}
+	err := fn()
+	w.timer.Reset(w.timeout)
+	return err
+}
+
+// deliver calls send for a message just received with the watchdog paused.
+func (w *watchdog) deliver(send func() error) error {
+	return w.pause(send)
+}
+
+// connectFunc opens one connection to a stream. It must call w.connected
+// once the connection is accepted and w.deliver for every message received,
+// anything else which may block, such as a request, must be run in w.pause.
+type connectFunc func(ctx context.Context, w *watchdog) error
+
+// reconnect keeps calling connect, reporting state changes to notify, until
+// ctx is done, a connection fails with an error which should not be retried,
+// or MaxAttempts reconnects fail in a row.
+func (opts ReconnectOptions) reconnect(ctx context.Context, connect connectFunc, notify func(StreamStateEvent) error) error {
+	attempt := 0
+	for {
+		connCtx, cancelConn := context.WithCancel(ctx)
+
+		stale := make(chan struct{})
+		var notifyErr error
+		w := &watchdog{
+			timeout: opts.HeartbeatTimeout,
+			timer: time.AfterFunc(opts.HeartbeatTimeout, func() {
+				close(stale)
+				cancelConn()
+			}),
+		}
+		w.connected = func() error {
+			attempt = 0
+			return w.pause(func() error {
+				notifyErr = notify(StreamStateEvent{State: StreamConnected})
+				return notifyErr
+			})
+		}
+
+		err := connect(connCtx, w)
+		w.timer.Stop()
+		cancelConn()
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if notifyErr != nil {
+			return notifyErr
+		}
+
+		select {
+		case <-stale:
+			err = ErrStreamStale
+			if err := notify(StreamStateEvent{State: StreamStale, Err: err}); err != nil {
+				return err
+			}
+		default:
+		}
+
+		if !retryable(err) {
+			return err
+		}
+		attempt++
+		if opts.MaxAttempts > 0 && attempt > opts.MaxAttempts {
+			return fmt.Errorf("gave up after %d reconnects: %w", opts.MaxAttempts, err)
+		}
+
+		delay := opts.backoff(attempt)
+		if err := notify(StreamStateEvent{State: StreamReconnecting, Err: err, Attempt: attempt, Delay: delay}); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// SubscribePricingReconnect method is the same as SubscribePricing but the
+// connection is watched for missed heartbeats and reopened, with jittered
+// exponential backoff, whenever it drops. A StreamStateEvent is sent on the
+// message channel every time the connection's state changes.
+//
+// The message channel is only closed once ctx is done, Oanda rejects the
+// request (i.e. an invalid token) or opts.MaxAttempts is exceeded. The
+// consumer must keep up with the channel, time spent blocked sending a
+// message does not count towards the heartbeat timeout.
+//
+// endpoint: /v3/accounts/{accountID}/pricing/stream
+func (c *Client) SubscribePricingReconnect(ctx context.Context, instruments []string, opts *ReconnectOptions) (<-chan PriceMessage, <-chan error) {
+	out := make(chan PriceMessage)
+	errc := make(chan error, 1)
+
+	send := func(msg PriceMessage) error {
+		select {
+		case out <- msg:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		errc <- opts.withDefaults().reconnect(ctx, func(ctx context.Context, w *watchdog) error {
+			return c.streamPricing(ctx, instruments, w.connected, func(msg PriceMessage) error {
+				return w.deliver(func() error { return send(msg) })
+			})
+		}, func(event StreamStateEvent) error {
+			return send(event)
+		})
+	}()
+
+	return out, errc
+}
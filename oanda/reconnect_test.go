@@ -0,0 +1,152 @@
+package oanda_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestSubscribePricingReconnect(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			// send one heartbeat then go quiet so the watchdog fires
+			fmt.Fprintln(w, `{"type":"HEARTBEAT","time":"2024-07-19T21:00:00.000000000Z"}`)
+		case 2:
+			// drop the connection straight away
+			return
+		default:
+			fmt.Fprintln(w, `{"type":"PRICE","instrument":"EUR_USD","bids":[{"price":"1.08","liquidity":1000000}],"asks":[{"price":"1.09","liquidity":1000000}]}`)
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithStreamURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, errc := client.SubscribePricingReconnect(ctx, []string{"EUR_USD"}, &oanda.ReconnectOptions{
+		HeartbeatTimeout: 100 * time.Millisecond,
+		MinBackoff:       10 * time.Millisecond,
+		MaxBackoff:       20 * time.Millisecond,
+	})
+
+	want := []string{
+		"connected",
+		"heartbeat",
+		"stale",
+		"reconnecting",
+		"connected",
+		"reconnecting",
+		"connected",
+		"price",
+	}
+	for i, w := range want {
+		var got string
+		switch msg := (<-messages).(type) {
+		case oanda.StreamStateEvent:
+			got = msg.State.String()
+		case oanda.HeartBeat:
+			got = "heartbeat"
+		case oanda.Stream:
+			got = "price"
+		}
+		if got != w {
+			t.Fatalf("message %d should be %s but is: %s", i, w, got)
+		}
+	}
+
+	cancel()
+	for range messages {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribePricingReconnect() should stop with context.Canceled but stopped with: %v", err)
+	}
+}
+
+func TestSubscribePricingReconnectUnauthorized(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errorMessage":"Insufficient authorization to perform request."}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithStreamURL(server.URL))
+
+	messages, errc := client.SubscribePricingReconnect(context.Background(), []string{"EUR_USD"}, nil)
+	for range messages {
+	}
+	if err := <-errc; !errors.Is(err, oanda.ErrUnauthorized) {
+		t.Fatalf("SubscribePricingReconnect() should stop with oanda.ErrUnauthorized but stopped with: %v", err)
+	}
+	if connections != 1 {
+		t.Fatalf("SubscribePricingReconnect() should not reconnect after a 401 but connected %d times", connections)
+	}
+}
+
+func TestSubscribePricingReconnectStreamURLUnset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request should be sent but got: %s", r.URL)
+	}))
+	defer server.Close()
+
+	// only the REST host is custom, the stream must not go to Oanda's practice host
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	messages, errc := client.SubscribePricingReconnect(context.Background(), []string{"EUR_USD"}, nil)
+	for range messages {
+	}
+	if err := <-errc; !errors.Is(err, oanda.ErrStreamURLUnset) {
+		t.Fatalf("SubscribePricingReconnect() should stop with oanda.ErrStreamURLUnset but stopped with: %v", err)
+	}
+}
+
+func TestSubscribePricingReconnectSlowConsumer(t *testing.T) {
+	var connections int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		w.(http.Flusher).Flush()
+		// the heartbeat arrives within the timeout of the connection being
+		// accepted, but only once the consumer has read the connected event
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintln(w, `{"type":"HEARTBEAT","time":"2024-07-19T21:00:00.000000000Z"}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithStreamURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, _ := client.SubscribePricingReconnect(ctx, []string{"EUR_USD"}, &oanda.ReconnectOptions{
+		HeartbeatTimeout: 100 * time.Millisecond,
+	})
+
+	if msg, ok := (<-messages).(oanda.StreamStateEvent); !ok || msg.State != oanda.StreamConnected {
+		t.Fatalf("first message should be connected but is: %+v", msg)
+	}
+	// block for longer than the heartbeat timeout before reading on
+	time.Sleep(300 * time.Millisecond)
+
+	if msg, ok := (<-messages).(oanda.HeartBeat); !ok {
+		t.Fatalf("a slow consumer should not make the stream stale but got: %+v", msg)
+	}
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Errorf("the stream should not reconnect but connected %d times", n)
+	}
+}
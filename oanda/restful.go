@@ -1,33 +1,59 @@
 // oanda package is a wrapper API for [Oanda-V20] RESTful API.
-// Currently this wrapper API only covers two endpoints:
+// It covers the following groups of endpoints, each as methods on Client:
 //
-//  1. Get request for [Instrument - candles endpoint] which returns
-//     historical OHLC Bid/Ask.
+//  1. [Account endpoints] for the account's details, summary, tradeable
+//     instruments and configuration, and polling it for changes.
 //
-//     - Parameters requires instrument symbol, token, and granularity (i.e., 'S5' for 5 second candles)
+//     - AccountTracker keeps an up to date copy of an account between polls.
 //
-//  2. Get JSON Stream for [Pricing - stream endpoint]
-//     which returns live Bid/Ask.
+//  2. [Instrument endpoints] for historical OHLC candles and the order and
+//     position books.
 //
-//     - Parameters requires list of instruments, token, and id
+//     - GetHistory and StreamHistory download any time range of candles.
+//     - LoadInstruments returns a registry of the account's instruments.
+//
+//  3. [Order endpoints], [Trade endpoints] and [Position endpoints] for
+//     placing, modifying and closing orders, trades and positions.
+//
+//  4. [Transaction endpoints] for the account's transaction history and
+//     its transaction stream.
+//
+//  5. [Pricing endpoints] for price snapshots, the latest candles and the
+//     live Bid/Ask price stream.
+//
+//     - SubscribePricingReconnect reopens the price stream when it drops.
+//
+// Prices and amounts are sent by Oanda as strings, the Decimal type does
+// exact arithmetic on them and response types have accessors returning them
+// as Decimals.
+//
+// Every Client method which sends a request takes a context.Context as its
+// first parameter, the request is cancelled when ctx is done. The methods
+// which predate contexts (GetAccounts, GetAccountID, GetAccountSummary,
+// GetAccountInstru, GetAccountChanges and GetCandlesBA) keep their original
+// signatures and each has an XxxContext variant which takes ctx. Newer
+// endpoints only have the form taking ctx, there are no Context variants.
 //
 // Don't forget to check Oanda's [Best Practices] before querying any
 // of their endpoints.
 //
 // [Oanda-V20]: https://developer.oanda.com/rest-live-v20/introduction/
-// [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
-// [Pricing - stream endpoint]: https://developer.oanda.com/rest-live-v20/pricing-ep/
+// [Account endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
+// [Instrument endpoints]: https://developer.oanda.com/rest-live-v20/instrument-ep/
+// [Order endpoints]: https://developer.oanda.com/rest-live-v20/order-ep/
+// [Trade endpoints]: https://developer.oanda.com/rest-live-v20/trade-ep/
+// [Position endpoints]: https://developer.oanda.com/rest-live-v20/position-ep/
+// [Transaction endpoints]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+// [Pricing endpoints]: https://developer.oanda.com/rest-live-v20/pricing-ep/
 // [Best Practices]: https://developer.oanda.com/rest-live-v20/best-practices/
 package oanda
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
-	"net/http"
 	"os"
-	"time"
 )
 
 // struct for unmarshalling primary account in `res.json` file which
@@ -42,10 +68,14 @@ type Account struct {
 }
 
 // struct for unmarshalling all accounts in `res.json` file which
-// contains account information (i.e., id and token).
+// contains account information (i.e., id and token) and optionally
+// which environment the account belongs to (see Environment).
 type Credential struct {
-	ID    string `json:"id"`
-	Token string `json:"token"`
+	ID          string      `json:"id"`
+	Token       string      `json:"token"`
+	Environment Environment `json:"environment,omitempty"`
+	BaseURL     string      `json:"url,omitempty"`
+	StreamURL   string      `json:"streamUrl,omitempty"`
 }
 
 type Credentials struct {
@@ -68,20 +98,24 @@ func (d *Credentials) UnmarshalJSON(data []byte) error {
 //
 // [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
 type Metadata struct {
-	Instrument  string `json:"instrument"`
-	Granularity string `json:"granularity"`
-	Candles     []OHLC `json:"candles"`
+	Instrument  string      `json:"instrument"`
+	Granularity Granularity `json:"granularity"`
+	Candles     []OHLC      `json:"candles"`
 }
 
 // struct for unmarshalling FOREX OHLC data from Oanda's [Instrument - candles endpoint].
 //
+// Bid, Ask and Mid are only set when requested with the price
+// parameter (see CandlesRequest), GetCandlesBA requests Bid and Ask.
+//
 // [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
 type OHLC struct {
-	Complete bool   `json:"complete"`
-	Volume   int    `json:"volume"`
-	Time     string `json:"time"`
-	Bid      Bid    `json:"bid"`
-	Ask      Ask    `json:"ask"`
+	Complete bool `json:"complete"`
+	Volume   int  `json:"volume"`
+	Time     Time `json:"time"`
+	Bid      Bid  `json:"bid"`
+	Ask      Ask  `json:"ask"`
+	Mid      Mid  `json:"mid"`
 }
 
 type Bid struct {
@@ -98,37 +132,29 @@ type Ask struct {
 	C string `json:"c"`
 }
 
+type Mid struct {
+	O string `json:"o"`
+	H string `json:"h"`
+	L string `json:"l"`
+	C string `json:"c"`
+}
+
 /*
-FormatTime function will format the time, as specified by input, by parsing a OHLC time string into a go lang time.Time type and then return time in string format.
+FormatTime function will format the time of the OHLC, as specified by input, and return time in string format.
+An error is returned if the OHLC has no time.
 */
-func (ohlc *OHLC) FormatTime(format string) string {
-	timestamp, err := time.Parse(time.RFC3339, ohlc.Time)
-	if err != nil {
-		log.Fatalf("error parsing timestamp: %v", err)
+func (ohlc *OHLC) FormatTime(format string) (string, error) {
+	if ohlc.Time.IsZero() {
+		return "", fmt.Errorf("error formatting timestamp: candle has no time")
 	}
-	return timestamp.Format(format)
+	return ohlc.Time.Format(format), nil
 }
 
-// struct for unmarshalling json data from Oanda's [Pricing - stream endpoint].
+// Stream is a price from Oanda's [Pricing - stream endpoint], the same as
+// the ClientPrice returned by GetPricing.
 //
 // [Pricing - stream endpoint]: https://developer.oanda.com/rest-live-v20/pricing-ep/
-type Stream struct {
-	Type string `json:"type"`
-	Time string `json:"time"`
-	Bids [1]struct {
-		Price     string `json:"price"`
-		Liquidity int64  `json:"liquidity"`
-	} `json:"bids"`
-	Asks [1]struct {
-		Price     string `json:"price"`
-		Liquidity int64  `json:"liquidity"`
-	} `json:"asks"`
-	CloseOutBid string `json:"closeoutbid"`
-	CloseOutAsk string `json:"closeoutask"`
-	Status      string `json:"status"`
-	Tradeable   bool   `json:"tradeable"`
-	Instrument  string `json:"instrument"`
-}
+type Stream = ClientPrice
 
 // struct for unmarshalling json data from Oanda's [Pricing - stream endpoint].
 //
@@ -138,7 +164,7 @@ type Stream struct {
 // [Pricing - stream endpoint]: https://developer.oanda.com/rest-live-v20/pricing-ep/
 type HeartBeat struct {
 	Type string `json:"type"`
-	Time string `json:"time"`
+	Time Time   `json:"time"`
 }
 
 // GetIdToken function will return id & token for primary account.
@@ -148,13 +174,8 @@ type HeartBeat struct {
 //
 // [Demo Account]: https://fxtrade.oanda.com/your_account/fxtrade/register/gate?utm_source=oandaapi&utm_medium=link&utm_campaign=devportaldocs_demo
 func GetIdToken(file_path string, display bool) (*PrimaryAccount, error) {
-	log.SetFlags(log.Ldate | log.Lshortfile)
 	jsonFile, err := os.Open(file_path)
-
-	// change log.Fatal to log.Print for resful_test.go to work
-	// specifically the TestGetIdTokenInvalidPath() test function
 	if err != nil {
-		// log.Print("error opening json file: ", err)
 		return nil, err
 	}
 
@@ -164,7 +185,6 @@ func GetIdToken(file_path string, display bool) (*PrimaryAccount, error) {
 	// read our opened jsonFile as a byte array.
 	byteValue, err := io.ReadAll(jsonFile)
 	if err != nil {
-		log.Print("error during ioutil.ReadAll(jsonFile): ", err)
 		return nil, err
 	}
 
@@ -174,11 +194,7 @@ func GetIdToken(file_path string, display bool) (*PrimaryAccount, error) {
 	// we unmarshal our byteArray which contains our
 	// jsonFile's content into 'account' which we defined above
 	err = json.Unmarshal(byteValue, &account)
-
-	// change log.Fatal to log.Print for resful_test.go to work
-	// specifically the TestGetIdTokenInvalidPath() test function
 	if err != nil {
-		// log.Print("error unmarshaling json: ", err)
 		return nil, err
 	}
 	// Print the account ID and Token to the console
@@ -198,13 +214,8 @@ func GetIdToken(file_path string, display bool) (*PrimaryAccount, error) {
 //
 // [Demo Account]: https://fxtrade.oanda.com/your_account/fxtrade/register/gate?utm_source=oandaapi&utm_medium=link&utm_campaign=devportaldocs_demo
 func GetAllIdToken(file_path string, display bool) (*Credentials, error) {
-	log.SetFlags(log.Ldate | log.Lshortfile)
 	jsonFile, err := os.Open(file_path)
-
-	// change log.Fatal to log.Print for resful_test.go to work
-	// specifically the TestGetIdTokenInvalidPath() test function
 	if err != nil {
-		log.Print("error opening json file: ", err)
 		return nil, err
 	}
 	defer jsonFile.Close()
@@ -212,7 +223,7 @@ func GetAllIdToken(file_path string, display bool) (*Credentials, error) {
 	var credentials Credentials
 	decoder := json.NewDecoder(jsonFile)
 	if err := decoder.Decode(&credentials); err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("error decoding json: %w", err)
 	}
 
 	// Output the dynamically captured fields
@@ -222,7 +233,7 @@ func GetAllIdToken(file_path string, display bool) (*Credentials, error) {
 		}
 	}
 
-	return &credentials, err
+	return &credentials, nil
 }
 
 // Get Request for Instrument endpoint - returns historical OHLC Bid/Ask.
@@ -231,62 +242,35 @@ func GetAllIdToken(file_path string, display bool) (*Credentials, error) {
 // See [Instrument - candles endpoint]
 //
 // [Instrument - candles endpoint]: https://developer.oanda.com/rest-live-v20/instrument-ep/
-func GetCandlesBA(instrument, granularity, token string, display bool) (*Metadata, error) {
-	url := "https://api-fxpractice.oanda.com/v3/instruments/" + instrument + "/candles"
-
-	// declare http client request, set to timeout after 10 seconds
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-
-	// preapre http get request with url
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	}
-
-	// set headers for get request
-	// check Oandas Best Practices for guidance https://developer.oanda.com/rest-live-v20/best-practices/
-	// and check their instrument endpoint for candles https://developer.oanda.com/rest-live-v20/instrument-ep/
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", "Bearer "+token)
-	req.Header.Add("Accept-Datetime-Format", "RFC3339")
-
-	// query parameters for get request
-	q := req.URL.Query()
-	q.Add("granularity", granularity)
-	q.Add("price", "BA")
-	// encore the url
-	req.URL.RawQuery = q.Encode()
-
-	// print string to console for debugging
-	// fmt.Println(req.URL.String())
-
-	response, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error: %s", err.Error())
-	} else if response.StatusCode == 400 {
-		return nil, fmt.Errorf("400 error: %d", response.StatusCode)
-	}
-	defer response.Body.Close()
+func GetCandlesBA(instrument string, granularity string, token string, display bool) (*Metadata, error) {
+	return NewClient(WithToken(token)).GetCandlesBA(instrument, granularity, display)
+}
 
-	// response body is []byte
-	body, err := io.ReadAll(response.Body)
+// GetCandlesBA method sends a Get Request for Instrument endpoint - returns historical OHLC Bid/Ask.
+//   - Parameters requires instrument symbol and granularity (i.e., 'S5' for 5 second candles)
+//
+// endpoint: /v3/instruments/{instrument}/candles
+func (c *Client) GetCandlesBA(instrument string, granularity string, display bool) (*Metadata, error) {
+	return c.GetCandlesBAContext(context.Background(), instrument, granularity, display)
+}
 
-	if err != nil {
-		log.Fatalln(err)
+// GetCandlesBAContext method is the same as GetCandlesBA but the request is
+// cancelled when ctx is done.
+func (c *Client) GetCandlesBAContext(ctx context.Context, instrument string, granularity string, display bool) (*Metadata, error) {
+	if err := Granularity(granularity).Validate(); err != nil {
+		return nil, err
 	}
-
-	// unmarshal the json data from get response
-	var candles Metadata
-	err = json.Unmarshal(body, &candles)
+	candles, err := c.GetCandles(ctx, instrument, &CandlesRequest{
+		Granularity: Granularity(granularity),
+		Price:       "BA",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+		return nil, err
 	}
 
 	// if display parameter is true for GetCandlesBA() func
 	// then print the get response
-	if display {
+	if display && len(candles.Candles) > 0 {
 		candle_count := len(candles.Candles)
 		mostRecentCandle := &candles.Candles[candle_count-1]
 		fmt.Printf("Instrument: \t\t%s\n", candles.Instrument)
@@ -307,5 +291,5 @@ func GetCandlesBA(instrument, granularity, token string, display bool) (*Metadat
 		fmt.Printf("\t\tClose: \t%s\n", mostRecentCandle.Ask.C)
 	}
 
-	return &candles, err
+	return candles, nil
 }
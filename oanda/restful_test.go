@@ -1,12 +1,14 @@
 package oanda_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/davidhintelmann/Oanda-Go/oanda"
 )
@@ -120,3 +122,33 @@ func Example_candles() {
 	fmt.Println(data.Granularity)
 	fmt.Println(data.Instrument)
 }
+
+func TestGetAllIdTokenInvalidJSON(t *testing.T) {
+	// "../LICENSE" can be opened but is not json, this must return an
+	// error instead of exiting the process
+	_, err := oanda.GetAllIdToken("../LICENSE", false)
+	if err == nil {
+		t.Fatal("GetAllIdToken(file_path, display) should fail when file_path is not json")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	// both of Oanda's datetime formats must decode to the same time
+	for _, data := range []string{`{"time":"2024-07-19T20:59:55.000000000Z"}`, `{"time":"1721422795.000000000"}`} {
+		var ohlc oanda.OHLC
+		if err := json.Unmarshal([]byte(data), &ohlc); err != nil {
+			t.Fatalf("error unmarshaling %s: %v", data, err)
+		}
+		formatted, err := ohlc.FormatTime(time.DateTime)
+		if err != nil {
+			t.Fatalf("FormatTime(format) produced an error: %v", err)
+		}
+		if formatted != "2024-07-19 20:59:55" {
+			t.Fatalf("FormatTime(format) should return '2024-07-19 20:59:55' but returned: %s", formatted)
+		}
+	}
+
+	if _, err := (&oanda.OHLC{}).FormatTime(time.DateTime); err == nil {
+		t.Fatal("FormatTime(format) should fail when OHLC has no time")
+	}
+}
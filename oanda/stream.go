@@ -0,0 +1,156 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PriceMessage is sent on the channel returned by SubscribePricing,
+// use a type switch to tell the messages apart:
+//
+//	switch msg := msg.(type) {
+//	case oanda.Stream:
+//		fmt.Println(msg.Instrument, msg.Bids[0].Price)
+//	case oanda.HeartBeat:
+//		fmt.Println("heartbeat at", msg.Time)
+//	}
+type PriceMessage interface {
+	priceMessage()
+}
+
+func (Stream) priceMessage()    {}
+func (HeartBeat) priceMessage() {}
+
+// streamHTTPClient returns a copy of the client's http.Client without a
+// timeout, a timeout would close the stream no matter how healthy it is.
+func (c *Client) streamHTTPClient() *http.Client {
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	return &streamClient
+}
+
+// stream sends a GET request for path on the streaming host and calls fn
+// with the "type" field and raw json of every line received, until the
+// stream ends, fn returns an error or ctx is done. If connected is not
+// nil it is called once Oanda accepts the request, an error returned from
+// it closes the stream.
+func (c *Client) stream(ctx context.Context, path string, query url.Values, connected func() error, fn func(msgType string, raw json.RawMessage) error) error {
+	if c.env == Custom && !c.streamURLSet {
+		return ErrStreamURLUnset
+	}
+
+	req, err := c.newHostRequest(ctx, c.streamURL, "GET", path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.streamHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			return fmt.Errorf("error reading response body: %s", err.Error())
+		}
+		return newAPIError(response, body)
+	}
+	if connected != nil {
+		if err := connected(); err != nil {
+			return err
+		}
+	}
+
+	// every message is a json object on its own line
+	decoder := json.NewDecoder(response.Body)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("error decoding stream: %w", err)
+		}
+
+		var msg struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			return fmt.Errorf("error unmarshaling json: %s", err.Error())
+		}
+		if err := fn(msg.Type, raw); err != nil {
+			return err
+		}
+	}
+}
+
+// streamPricing connects to the pricing stream and sends every price and
+// heartbeat to send, until the connection drops or ctx is done.
+func (c *Client) streamPricing(ctx context.Context, instruments []string, connected func() error, send func(PriceMessage) error) error {
+	q := url.Values{}
+	q.Add("instruments", strings.Join(instruments, ","))
+
+	return c.stream(ctx, c.accountPath("/pricing/stream"), q, connected, func(msgType string, raw json.RawMessage) error {
+		var msg PriceMessage
+		switch msgType {
+		case "PRICE":
+			var price Stream
+			if err := json.Unmarshal(raw, &price); err != nil {
+				return fmt.Errorf("error unmarshaling json: %s", err.Error())
+			}
+			msg = price
+		case "HEARTBEAT":
+			var heartbeat HeartBeat
+			if err := json.Unmarshal(raw, &heartbeat); err != nil {
+				return fmt.Errorf("error unmarshaling json: %s", err.Error())
+			}
+			msg = heartbeat
+		default:
+			// ignore message types added by Oanda after this was written
+			return nil
+		}
+		return send(msg)
+	})
+}
+
+// SubscribePricing method connects to Oanda's [Pricing - stream endpoint] for the
+// client's account and sends a Stream for every price and a HeartBeat every
+// 5 seconds on the returned channel.
+//
+// The message channel is closed when the connection drops or ctx is done,
+// after which the error channel receives the reason. Cancel ctx to stop
+// the subscription.
+//
+// endpoint: /v3/accounts/{accountID}/pricing/stream
+//
+// [Pricing - stream endpoint]: https://developer.oanda.com/rest-live-v20/pricing-ep/
+func (c *Client) SubscribePricing(ctx context.Context, instruments []string) (<-chan PriceMessage, <-chan error) {
+	out := make(chan PriceMessage)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		errc <- c.streamPricing(ctx, instruments, nil, func(msg PriceMessage) error {
+			select {
+			case out <- msg:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return out, errc
+}
@@ -0,0 +1,91 @@
+package oanda_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestSubscribePricing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/pricing/stream" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("instruments"); got != "EUR_USD,USD_CAD" {
+			t.Errorf("instruments should be 'EUR_USD,USD_CAD' but is: %s", got)
+		}
+		fmt.Fprintln(w, `{"type":"PRICE","time":"2024-07-19T20:59:55.000000000Z","bids":[{"price":"1.08","liquidity":1000000},{"price":"1.07","liquidity":5000000}],"asks":[{"price":"1.09","liquidity":1000000}],"closeoutBid":"1.07","closeoutAsk":"1.10","tradeable":true,"instrument":"EUR_USD"}`)
+		fmt.Fprintln(w, `{"type":"HEARTBEAT","time":"2024-07-19T21:00:00.000000000Z"}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithStreamURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, errc := client.SubscribePricing(ctx, []string{"EUR_USD", "USD_CAD"})
+
+	price, ok := (<-messages).(oanda.Stream)
+	if !ok || price.Instrument != "EUR_USD" || price.Bids[0].Price != "1.08" {
+		t.Fatalf("first message should be the EUR_USD price but is: %+v", price)
+	}
+	if len(price.Bids) != 2 || price.Bids[1].Liquidity != 5000000 || price.CloseOutBid != "1.07" || price.CloseOutAsk != "1.10" {
+		t.Errorf("every bucket and the closeout prices should be decoded but price is: %+v", price)
+	}
+	heartbeat, ok := (<-messages).(oanda.HeartBeat)
+	if !ok || !heartbeat.Time.Equal(time.Date(2024, 7, 19, 21, 0, 0, 0, time.UTC)) {
+		t.Fatalf("second message should be a heartbeat but is: %+v", heartbeat)
+	}
+
+	cancel()
+	for range messages {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribePricing() should stop with context.Canceled but stopped with: %v", err)
+	}
+}
+
+func TestStreamVWAP(t *testing.T) {
+	price := oanda.Stream{
+		Bids: oanda.PriceBuckets{{Price: "1.08", Liquidity: 1000}, {Price: "1.07", Liquidity: 3000}},
+		Asks: oanda.PriceBuckets{{Price: "1.09", Liquidity: 2000}, {Price: "1.10", Liquidity: 2000}},
+	}
+
+	tests := []struct {
+		units string
+		want  string
+	}{
+		{"500", "1.090000"},
+		// (2000*1.09 + 1000*1.10) / 3000
+		{"3000", "1.093333"},
+		{"-1000", "1.080000"},
+		// (1000*1.08 + 1000*1.07) / 2000
+		{"-2000", "1.075000"},
+	}
+	for _, tt := range tests {
+		got, err := price.VWAP(oanda.MustParseDecimal(tt.units))
+		if err != nil {
+			t.Errorf("VWAP(%s) produced an error: %v", tt.units, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("VWAP(%s) should be %s but is: %s", tt.units, tt.want, got)
+		}
+	}
+
+	if _, err := price.VWAP(oanda.NewDecimal(5000, 0)); !errors.Is(err, oanda.ErrInsufficientLiquidity) {
+		t.Errorf("VWAP(5000) should fail with oanda.ErrInsufficientLiquidity but returned: %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package oanda
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatetimeFormat is the format Oanda uses for times in requests and
+// responses, set with WithDatetimeFormat.
+type DatetimeFormat int
+
+const (
+	// RFC3339 times such as "2017-08-11T15:38:20.000000000Z", the default.
+	RFC3339 DatetimeFormat = iota
+	// UNIX times in seconds such as "1502473100.000000000".
+	UNIX
+)
+
+// String returns the value sent in the Accept-Datetime-Format header.
+func (f DatetimeFormat) String() string {
+	if f == UNIX {
+		return "UNIX"
+	}
+	return "RFC3339"
+}
+
+// format returns t as a query parameter in the format.
+func (f DatetimeFormat) format(t time.Time) string {
+	if f == UNIX {
+		return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// WithDatetimeFormat option sets the format Oanda uses for times in requests
+// and responses. Responses in either format decode into Time, so this only
+// matters to code reading the raw json.
+func WithDatetimeFormat(format DatetimeFormat) Option {
+	return func(c *Client) {
+		c.datetimeFormat = format
+	}
+}
+
+/*
+Time is a time.Time which unmarshals from both of Oanda's datetime formats,
+RFC3339 ("2017-08-11T15:38:20.000000000Z") and UNIX ("1502473100.000000000").
+It marshals as RFC3339 with nanoseconds, a zero Time as "".
+*/
+type Time struct {
+	time.Time
+}
+
+// ParseTime parses a time in either of Oanda's datetime formats, RFC3339
+// times are told apart by the "T" between the date and time.
+func ParseTime(s string) (Time, error) {
+	if strings.Contains(s, "T") {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return Time{}, fmt.Errorf("error parsing timestamp: %w", err)
+		}
+		return Time{t}, nil
+	}
+
+	secs, frac, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil || len(frac) > 9 {
+		return Time{}, fmt.Errorf("error parsing timestamp: %q is not a UNIX time", s)
+	}
+	var nsec int64
+	if frac != "" {
+		// pad to nanoseconds, ".5" is half a second
+		n, err := strconv.ParseUint(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return Time{}, fmt.Errorf("error parsing timestamp: %q is not a UNIX time", s)
+		}
+		nsec = int64(n)
+		// the fraction of a time before 1970 is also negative
+		if strings.HasPrefix(secs, "-") {
+			nsec = -nsec
+		}
+	}
+	return Time{time.Unix(sec, nsec).UTC()}, nil
+}
+
+// MarshalJSON encodes t as an RFC3339 string with nanoseconds.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339Nano))
+}
+
+// UnmarshalJSON decodes a time in either of Oanda's datetime formats,
+// quoted or not. null and "" decode as the zero Time.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	if s == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := ParseTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
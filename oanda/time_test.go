@@ -0,0 +1,108 @@
+package oanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2017, 8, 11, 17, 38, 20, 500000000, time.UTC)
+	for _, in := range []string{"2017-08-11T17:38:20.500000000Z", "2017-08-11T19:38:20.5+02:00", "1502473100.500000000", "1502473100.5"} {
+		got, err := oanda.ParseTime(in)
+		if err != nil {
+			t.Errorf("ParseTime(%q) produced an error: %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) should be %s but is: %s", in, want, got)
+		}
+	}
+
+	before1970 := time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC)
+	for _, in := range []string{"-1.5", "-1.500000000", "1969-12-31T23:59:58.5Z"} {
+		if got, err := oanda.ParseTime(in); err != nil || !got.Equal(before1970) {
+			t.Errorf("ParseTime(%q) should be %s but is: %s, %v", in, before1970, got, err)
+		}
+	}
+
+	for _, in := range []string{"yesterday", "2017-08-11", "1502473100.0000000001", "-1.-5"} {
+		if _, err := oanda.ParseTime(in); err == nil {
+			t.Errorf("ParseTime(%q) should return an error", in)
+		}
+	}
+}
+
+func TestTimeJSON(t *testing.T) {
+	var v struct {
+		Time  oanda.Time `json:"time"`
+		Empty oanda.Time `json:"empty"`
+	}
+	if err := json.Unmarshal([]byte(`{"time":"1502473100.000000000","empty":""}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal() produced an error: %v", err)
+	}
+	if v.Time.Unix() != 1502473100 || !v.Empty.IsZero() {
+		t.Errorf("times were not decoded: %+v", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if want := `{"time":"2017-08-11T17:38:20Z","empty":""}`; string(data) != want {
+		t.Errorf("json.Marshal() should return %s but returned: %s", want, data)
+	}
+}
+
+func TestWithDatetimeFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Datetime-Format"); got != "UNIX" {
+			t.Errorf("Accept-Datetime-Format should be UNIX but is: %s", got)
+		}
+		if got := r.URL.Query().Get("from"); got != "1502473100.000000000" {
+			t.Errorf("from should be a UNIX time but is: %s", got)
+		}
+		fmt.Fprint(w, `{"instrument":"EUR_USD","granularity":"S5","candles":[{"complete":true,"time":"1502473100.000000000"}]}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithDatetimeFormat(oanda.UNIX),
+	)
+
+	from := time.Unix(1502473100, 0)
+	candles, err := client.GetCandles(context.Background(), "EUR_USD", &oanda.CandlesRequest{From: from})
+	if err != nil {
+		t.Fatalf("GetCandles() produced an error: %v", err)
+	}
+	if !candles.Candles[0].Time.Equal(from) {
+		t.Errorf("candle time should be %s but is: %s", from, candles.Candles[0].Time)
+	}
+}
+
+func TestOrderRequestGtdTime(t *testing.T) {
+	gtd := oanda.Time{Time: time.Date(2024, 7, 19, 21, 0, 0, 0, time.UTC)}
+	data, err := json.Marshal(oanda.LimitOrderRequest{Instrument: "EUR_USD", Units: "100", Price: "1.08", TimeInForce: oanda.TimeInForceGTD, GtdTime: &gtd})
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if !strings.Contains(string(data), `"gtdTime":"2024-07-19T21:00:00Z"`) {
+		t.Errorf("gtdTime should be sent as RFC3339 but json is: %s", data)
+	}
+
+	data, err = json.Marshal(oanda.LimitOrderRequest{Instrument: "EUR_USD", Units: "100", Price: "1.08"})
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if strings.Contains(string(data), "gtdTime") {
+		t.Errorf("gtdTime should not be sent when it is not set but json is: %s", data)
+	}
+}
@@ -0,0 +1,279 @@
+package oanda
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+/*
+AccountTracker keeps an in-memory snapshot of an account up to date by
+polling Oanda's [Account - changes endpoint], which is much cheaper than
+fetching the full account every time.
+
+	tracker, err := client.NewAccountTracker(ctx)
+	if err != nil {
+		return err
+	}
+	go tracker.Run(ctx, 5*time.Second)
+
+	account := tracker.Account()
+	fmt.Println(account.NAV, len(account.Trades))
+
+It is safe to use from multiple goroutines.
+
+[Account - changes endpoint]: https://developer.oanda.com/rest-live-v20/account-ep/
+*/
+type AccountTracker struct {
+	client *Client
+
+	// updateMu serialises Update so a slow poll can never be applied
+	// after the poll which followed it
+	updateMu sync.Mutex
+
+	mu      sync.RWMutex
+	account IdDetails
+	lastID  string
+}
+
+/*
+NewAccountTracker method fetches the full details of the client's account
+and returns an AccountTracker starting from them.
+
+endpoint: /v3/accounts/{accountID}
+*/
+func (c *Client) NewAccountTracker(ctx context.Context) (*AccountTracker, error) {
+	details, err := c.GetAccountIDContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountTracker{
+		client:  c,
+		account: details.Account,
+		lastID:  details.LastTransactionID,
+	}, nil
+}
+
+// Account returns a copy of the current snapshot of the account.
+func (t *AccountTracker) Account() IdDetails {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	account := t.account
+	account.Orders = slices.Clone(t.account.Orders)
+	account.Trades = slices.Clone(t.account.Trades)
+	account.Positions = slices.Clone(t.account.Positions)
+	return account
+}
+
+// LastTransactionID returns the ID of the last transaction applied to the snapshot.
+func (t *AccountTracker) LastTransactionID() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lastID
+}
+
+/*
+Update method polls for the changes since the last update and applies them
+to the snapshot, the changes are returned so callers can act on them.
+Concurrent calls are run one at a time, each polling from where the
+previous one finished.
+
+endpoint: /v3/accounts/{accountID}/changes
+*/
+func (t *AccountTracker) Update(ctx context.Context) (*AccountChange, error) {
+	t.updateMu.Lock()
+	defer t.updateMu.Unlock()
+
+	change, err := t.client.GetAccountChangesContext(ctx, t.LastTransactionID())
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.account.apply(change)
+	t.lastID = change.LastTransactionID
+	return change, nil
+}
+
+/*
+Run method calls Update every interval until ctx is done or an update
+fails, it returns the error which stopped it.
+*/
+func (t *AccountTracker) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if _, err := t.Update(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// apply updates the account with the changes and state from polling /changes.
+func (d *IdDetails) apply(change *AccountChange) {
+	d.applyChanges(&change.Changes)
+	d.applyState(&change.State)
+
+	d.LastTransactionID = change.LastTransactionID
+	d.PendingOrderCount = len(d.Orders)
+	d.OpenTradeCount = len(d.Trades)
+	d.OpenPositionCount = 0
+	for _, position := range d.Positions {
+		if !isZeroUnits(position.Long.Units) || !isZeroUnits(position.Short.Units) {
+			d.OpenPositionCount++
+		}
+	}
+}
+
+// applyChanges adds, replaces and removes the orders, trades and positions
+// which changed. Orders created and filled between two polls appear in both
+// lists, so orders are added before any are removed.
+func (d *IdDetails) applyChanges(ch *Changes) {
+	for _, order := range ch.OrdersCreated {
+		d.Orders = replaceOrder(d.Orders, order)
+	}
+	for _, orders := range [][]Order{ch.OrdersFilled, ch.OrdersCancelled, ch.OrdersTriggered} {
+		for _, order := range orders {
+			id := order.Base().ID
+			d.Orders = slices.DeleteFunc(d.Orders, func(o Order) bool { return o.Base().ID == id })
+		}
+	}
+
+	for _, trade := range ch.TradesOpened {
+		d.Trades = replaceTrade(d.Trades, trade)
+	}
+	for _, trade := range ch.TradesReduced {
+		d.Trades = replaceTrade(d.Trades, trade)
+	}
+	for _, trade := range ch.TradesClosed {
+		d.Trades = slices.DeleteFunc(d.Trades, func(t TradeSummary) bool { return t.ID == trade.ID })
+	}
+
+	for _, position := range ch.Positions {
+		i := slices.IndexFunc(d.Positions, func(p PositionsID) bool { return p.Instrument == position.Instrument })
+		if i < 0 {
+			d.Positions = append(d.Positions, position)
+			continue
+		}
+		// changes do not include the price dependent fields, those
+		// come from the state
+		position.UnrealizedPL = d.Positions[i].UnrealizedPL
+		position.MarginUsed = d.Positions[i].MarginUsed
+		position.Long.UnrealizedPL = d.Positions[i].Long.UnrealizedPL
+		position.Short.UnrealizedPL = d.Positions[i].Short.UnrealizedPL
+		d.Positions[i] = position
+	}
+
+	for _, tx := range ch.Transactions {
+		if configure, ok := tx.(*ClientConfigureTransaction); ok {
+			if configure.Alias != "" {
+				d.Alias = configure.Alias
+			}
+			if configure.MarginRate != "" {
+				d.MarginRate = configure.MarginRate
+			}
+		}
+	}
+}
+
+// applyState copies the price dependent fields onto the account, its orders,
+// trades and positions. Empty fields are left unchanged.
+func (d *IdDetails) applyState(s *State) {
+	set := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	set(&d.NAV, s.NAV)
+	set(&d.Balance, s.Balance)
+	set(&d.PL, s.PL)
+	set(&d.ResettablePL, s.ResettablePL)
+	set(&d.Financing, s.Financing)
+	set(&d.Commission, s.Commission)
+	set(&d.DividendAdjustment, s.DividendAdjustment)
+	set(&d.GuaranteedExecutionFees, s.GuaranteedExecutionFees)
+	set(&d.UnrealizedPL, s.UnrealizedPL)
+	set(&d.MarginUsed, s.MarginUsed)
+	set(&d.MarginAvailable, s.MarginAvailable)
+	set(&d.PositionValue, s.PositionValue)
+	set(&d.MarginCloseoutUnrealizedPL, s.MarginCloseoutUnrealizedPL)
+	set(&d.MarginCloseoutNAV, s.MarginCloseoutNAV)
+	set(&d.MarginCloseoutMarginUsed, s.MarginCloseoutMarginUsed)
+	set(&d.MarginCloseoutPositionValue, s.MarginCloseoutPositionValue)
+	set(&d.MarginCloseoutPercent, s.MarginCloseoutPercent)
+	set(&d.WithdrawalLimit, s.WithdrawalLimit)
+	set(&d.MarginCallMarginUsed, s.MarginCallMarginUsed)
+	set(&d.MarginCallPercent, s.MarginCallPercent)
+
+	for _, state := range s.Orders {
+		i := slices.IndexFunc(d.Orders, func(o Order) bool { return o.Base().ID == state.ID })
+		if i < 0 {
+			continue
+		}
+		if order, ok := d.Orders[i].(*TrailingStopLossOrder); ok && state.TrailingStopValue != "" {
+			// copy so orders returned by Account are never modified
+			updated := *order
+			updated.TrailingStopValue = state.TrailingStopValue
+			d.Orders[i] = &updated
+		}
+	}
+
+	for _, state := range s.Trades {
+		i := slices.IndexFunc(d.Trades, func(t TradeSummary) bool { return t.ID == state.ID })
+		if i < 0 {
+			continue
+		}
+		set(&d.Trades[i].UnrealizedPL, state.UnrealizedPL)
+		set(&d.Trades[i].MarginUsed, state.MarginUsed)
+	}
+
+	for _, state := range s.Positions {
+		i := slices.IndexFunc(d.Positions, func(p PositionsID) bool { return p.Instrument == state.Instrument })
+		if i < 0 {
+			continue
+		}
+		set(&d.Positions[i].UnrealizedPL, state.NetUnrealizedPL)
+		set(&d.Positions[i].Long.UnrealizedPL, state.LongUnrealizedPL)
+		set(&d.Positions[i].Short.UnrealizedPL, state.ShortUnrealizedPL)
+		set(&d.Positions[i].MarginUsed, state.MarginUsed)
+	}
+}
+
+// replaceOrder replaces the order with the same ID or appends it.
+func replaceOrder(orders []Order, order Order) []Order {
+	id := order.Base().ID
+	if i := slices.IndexFunc(orders, func(o Order) bool { return o.Base().ID == id }); i >= 0 {
+		orders[i] = order
+		return orders
+	}
+	return append(orders, order)
+}
+
+// replaceTrade replaces the trade with the same ID or appends it.
+func replaceTrade(trades []TradeSummary, trade TradeSummary) []TradeSummary {
+	if i := slices.IndexFunc(trades, func(t TradeSummary) bool { return t.ID == trade.ID }); i >= 0 {
+		trades[i] = trade
+		return trades
+	}
+	return append(trades, trade)
+}
+
+// isZeroUnits reports whether units is empty or a number equal to zero.
+func isZeroUnits(units string) bool {
+	if units == "" {
+		return true
+	}
+	d, err := ParseDecimal(units)
+	return err == nil && d.Sign() == 0
+}
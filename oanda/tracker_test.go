@@ -0,0 +1,153 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestAccountTracker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/accounts/101-001-1234567-001":
+			fmt.Fprint(w, `{"account":{
+	"id": "101-001-1234567-001", "NAV": "1000.0000", "balance": "1000.0000", "openTradeCount": 1, "pendingOrderCount": 2,
+	"orders": [
+		{"id": "5", "type": "LIMIT", "state": "PENDING", "instrument": "EUR_USD", "units": "100", "price": "1.05"},
+		{"id": "6", "type": "TRAILING_STOP_LOSS", "state": "PENDING", "tradeID": "4", "distance": "0.005", "trailingStopValue": "1.075"}
+	],
+	"trades": [{"id": "4", "instrument": "EUR_USD", "currentUnits": "100", "unrealizedPL": "0.1000", "trailingStopLossOrderID": "6"}],
+	"positions": [{"instrument": "EUR_USD", "long": {"units": "100", "tradeIDs": ["4"]}, "short": {"units": "0"}}]
+},"lastTransactionID":"6"}`)
+		case "/v3/accounts/101-001-1234567-001/changes":
+			if id := r.URL.Query().Get("sinceTransactionID"); id != "6" {
+				t.Errorf("sinceTransactionID should be 6 but is: %s", id)
+			}
+			fmt.Fprint(w, `{"changes":{
+	"ordersFilled": [{"id": "5", "type": "LIMIT", "state": "FILLED", "instrument": "EUR_USD", "units": "100", "price": "1.05"}],
+	"tradesOpened": [{"id": "7", "instrument": "EUR_USD", "currentUnits": "100", "price": "1.05"}],
+	"positions": [{"instrument": "EUR_USD", "long": {"units": "200", "tradeIDs": ["4", "7"]}, "short": {"units": "0"}}],
+	"transactions": [
+		{"id": "7", "type": "ORDER_FILL", "orderID": "5"},
+		{"id": "8", "type": "CLIENT_CONFIGURE", "alias": "tracked"}
+	]
+},"state":{
+	"NAV": "1001.5000", "unrealizedPL": "1.5000",
+	"orders": [{"id": "6", "trailingStopValue": "1.080"}],
+	"trades": [{"id": "4", "unrealizedPL": "1.0000"}, {"id": "7", "unrealizedPL": "0.5000"}],
+	"positions": [{"instrument": "EUR_USD", "netUnrealizedPL": "1.5000", "longUnrealizedPL": "1.5000"}]
+},"lastTransactionID":"8"}`)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	tracker, err := client.NewAccountTracker(context.Background())
+	if err != nil {
+		t.Fatalf("NewAccountTracker() produced an error: %v", err)
+	}
+	before := tracker.Account()
+
+	if _, err := tracker.Update(context.Background()); err != nil {
+		t.Fatalf("Update() produced an error: %v", err)
+	}
+
+	account := tracker.Account()
+	if account.NAV != "1001.5000" || account.Balance != "1000.0000" || account.Alias != "tracked" {
+		t.Errorf("account fields were not updated: NAV %s, balance %s, alias %q", account.NAV, account.Balance, account.Alias)
+	}
+	if tracker.LastTransactionID() != "8" || account.LastTransactionID != "8" {
+		t.Errorf("LastTransactionID should be 8 but is: %s", tracker.LastTransactionID())
+	}
+
+	if len(account.Orders) != 1 || account.PendingOrderCount != 1 {
+		t.Fatalf("filled limit order should be removed but orders are: %+v", account.Orders)
+	}
+	if trailing, ok := account.Orders[0].(*oanda.TrailingStopLossOrder); !ok || trailing.TrailingStopValue != "1.080" {
+		t.Errorf("trailing stop value should be 1.080 but order is: %+v", account.Orders[0])
+	}
+
+	if len(account.Trades) != 2 || account.OpenTradeCount != 2 {
+		t.Fatalf("opened trade should be added but trades are: %+v", account.Trades)
+	}
+	if account.Trades[0].UnrealizedPL != "1.0000" || account.Trades[1].UnrealizedPL != "0.5000" {
+		t.Errorf("trade unrealized PL was not updated: %s, %s", account.Trades[0].UnrealizedPL, account.Trades[1].UnrealizedPL)
+	}
+
+	if len(account.Positions) != 1 || account.Positions[0].Long.Units != "200" || account.Positions[0].UnrealizedPL != "1.5000" {
+		t.Errorf("position was not updated: %+v", account.Positions)
+	}
+	if account.OpenPositionCount != 1 {
+		t.Errorf("OpenPositionCount should be 1 but is: %d", account.OpenPositionCount)
+	}
+
+	// snapshots already returned must not change
+	if len(before.Orders) != 2 || before.Orders[1].(*oanda.TrailingStopLossOrder).TrailingStopValue != "1.075" {
+		t.Errorf("earlier snapshot was modified: %+v", before.Orders)
+	}
+}
+
+func TestAccountTrackerConcurrentUpdates(t *testing.T) {
+	var mu sync.Mutex
+	last := 6
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/accounts/101-001-1234567-001" {
+			fmt.Fprint(w, `{"account":{"id": "101-001-1234567-001"},"lastTransactionID":"6"}`)
+			return
+		}
+
+		since, _ := strconv.Atoi(r.URL.Query().Get("sinceTransactionID"))
+		mu.Lock()
+		if since != last {
+			t.Errorf("sinceTransactionID should be %d but is: %d", last, since)
+		}
+		last = since + 1
+		mu.Unlock()
+
+		// a slow poll which would finish after a newer one if they overlapped
+		time.Sleep(5 * time.Millisecond)
+		fmt.Fprintf(w, `{"changes":{},"state":{"NAV": "%d.0000"},"lastTransactionID":"%d"}`, since+1, since+1)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+	tracker, err := client.NewAccountTracker(context.Background())
+	if err != nil {
+		t.Fatalf("NewAccountTracker() produced an error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tracker.Update(context.Background()); err != nil {
+				t.Errorf("Update() produced an error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if id := tracker.LastTransactionID(); id != "16" {
+		t.Errorf("LastTransactionID() should be 16 after 10 updates but is: %s", id)
+	}
+	if nav := tracker.Account().NAV; nav != "16.0000" {
+		t.Errorf("NAV should be from the last update but is: %s", nav)
+	}
+}
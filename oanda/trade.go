@@ -0,0 +1,328 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Values for the state field of a trade.
+const (
+	TradeStateOpen               = "OPEN"
+	TradeStateClosed             = "CLOSED"
+	TradeStateCloseWhenTradeable = "CLOSE_WHEN_TRADEABLE"
+	TradeStateAll                = "ALL" // only valid as a filter, see TradesRequest
+)
+
+/*
+embedded struct with the fields shared by Trade and TradeSummary
+*/
+type TradeBase struct {
+	ID                    string            `json:"id"`
+	Instrument            string            `json:"instrument"`
+	Price                 string            `json:"price"`
+	OpenTime              Time              `json:"openTime"`
+	State                 string            `json:"state"`
+	InitialUnits          string            `json:"initialUnits"`
+	InitialMarginRequired string            `json:"initialMarginRequired"`
+	CurrentUnits          string            `json:"currentUnits"`
+	RealizedPL            string            `json:"realizedPL"`
+	UnrealizedPL          string            `json:"unrealizedPL,omitempty"`
+	MarginUsed            string            `json:"marginUsed,omitempty"`
+	AverageClosePrice     string            `json:"averageClosePrice,omitempty"`
+	ClosingTransactionIDs []string          `json:"closingTransactionIDs,omitempty"`
+	Financing             string            `json:"financing"`
+	DividendAdjustment    string            `json:"dividendAdjustment,omitempty"`
+	CloseTime             *Time             `json:"closeTime,omitempty"`
+	ClientExtensions      *ClientExtensions `json:"clientExtensions,omitempty"`
+}
+
+/*
+struct for unmarshalling a trade from [Trade Endpoints], including the full
+details of its dependent orders
+
+[Trade Endpoints]: https://developer.oanda.com/rest-live-v20/trade-ep/
+*/
+type Trade struct {
+	TradeBase
+	TakeProfitOrder         *TakeProfitOrder         `json:"takeProfitOrder,omitempty"`
+	StopLossOrder           *StopLossOrder           `json:"stopLossOrder,omitempty"`
+	GuaranteedStopLossOrder *GuaranteedStopLossOrder `json:"guaranteedStopLossOrder,omitempty"`
+	TrailingStopLossOrder   *TrailingStopLossOrder   `json:"trailingStopLossOrder,omitempty"`
+}
+
+/*
+struct for unmarshalling a trade from [Account Endpoints], the same as Trade
+but only with the IDs of its dependent orders
+
+endpoint: /v3/accounts/{accountID}
+
+[Account Endpoints]: https://developer.oanda.com/rest-live-v20/account-ep/
+*/
+type TradeSummary struct {
+	TradeBase
+	TakeProfitOrderID         string `json:"takeProfitOrderID,omitempty"`
+	StopLossOrderID           string `json:"stopLossOrderID,omitempty"`
+	GuaranteedStopLossOrderID string `json:"guaranteedStopLossOrderID,omitempty"`
+	TrailingStopLossOrderID   string `json:"trailingStopLossOrderID,omitempty"`
+}
+
+/*
+struct for unmarshalling a list of trades from [Trade Endpoints]
+
+endpoint: /v3/accounts/{accountID}/trades
+
+[Trade Endpoints]: https://developer.oanda.com/rest-live-v20/trade-ep/
+*/
+type TradeList struct {
+	Trades            []Trade `json:"trades"`
+	LastTransactionID string  `json:"lastTransactionID"`
+}
+
+/*
+struct for unmarshalling a single trade from [Trade Endpoints]
+
+endpoint: /v3/accounts/{accountID}/trades/{tradeSpecifier}
+
+[Trade Endpoints]: https://developer.oanda.com/rest-live-v20/trade-ep/
+*/
+type TradeDetails struct {
+	Trade             Trade  `json:"trade"`
+	LastTransactionID string `json:"lastTransactionID"`
+}
+
+// TradesRequest holds the filters for GetTrades, zero values are not sent.
+type TradesRequest struct {
+	// IDs of the trades to get.
+	IDs []string
+	// State of the trades to get, one of the TradeState constants.
+	// Defaults to TradeStateOpen.
+	State string
+	// Instrument of the trades to get.
+	Instrument string
+	// Count is the maximum number of trades to return, at most 500.
+	// Defaults to 50.
+	Count int
+	// BeforeID only returns trades with an ID less than this one.
+	BeforeID string
+}
+
+// query returns the request as url query parameters.
+func (r *TradesRequest) query() url.Values {
+	q := url.Values{}
+	if len(r.IDs) > 0 {
+		q.Add("ids", strings.Join(r.IDs, ","))
+	}
+	if r.State != "" {
+		q.Add("state", r.State)
+	}
+	if r.Instrument != "" {
+		q.Add("instrument", r.Instrument)
+	}
+	if r.Count > 0 {
+		q.Add("count", strconv.Itoa(r.Count))
+	}
+	if r.BeforeID != "" {
+		q.Add("beforeID", r.BeforeID)
+	}
+	return q
+}
+
+// ClientTradeSpecifier returns the trade specifier for a trade's client ID,
+// it can be used anywhere a trade ID is accepted.
+func ClientTradeSpecifier(clientID string) string {
+	return "@" + clientID
+}
+
+// tradePath returns the path for a trade specifier in the client's account.
+func (c *Client) tradePath(tradeSpecifier, endpoint string) string {
+	return c.accountPath("/trades/" + url.PathEscape(tradeSpecifier) + endpoint)
+}
+
+/*
+GetTrades method will return a list of trades for the client's account,
+a nil request returns the 50 most recent open trades.
+
+endpoint: /v3/accounts/{accountID}/trades
+*/
+func (c *Client) GetTrades(ctx context.Context, req *TradesRequest) (*TradeList, error) {
+	if req == nil {
+		req = &TradesRequest{}
+	}
+
+	var list TradeList
+	if err := c.get(ctx, c.accountPath("/trades"), req.query(), &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetOpenTrades method will return every open trade for the client's account.
+
+endpoint: /v3/accounts/{accountID}/openTrades
+*/
+func (c *Client) GetOpenTrades(ctx context.Context) (*TradeList, error) {
+	var list TradeList
+	if err := c.get(ctx, c.accountPath("/openTrades"), nil, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetTrade method will return a single trade for the client's account. The
+trade specifier is either the trade's ID or "@" followed by its client ID,
+see ClientTradeSpecifier.
+
+endpoint: /v3/accounts/{accountID}/trades/{tradeSpecifier}
+*/
+func (c *Client) GetTrade(ctx context.Context, tradeSpecifier string) (*TradeDetails, error) {
+	var details TradeDetails
+	if err := c.get(ctx, c.tradePath(tradeSpecifier, ""), nil, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+/*
+CloseTrade method will close a trade in the client's account, either fully
+when units is "ALL" or partially by the given (positive) number of units.
+
+The market order created to close the trade is OrderCreateTransaction.
+
+endpoint: /v3/accounts/{accountID}/trades/{tradeSpecifier}/close
+*/
+func (c *Client) CloseTrade(ctx context.Context, tradeSpecifier, units string) (*OrderCreateResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		Units string `json:"units"`
+	}{units}
+
+	var response OrderCreateResponse
+	if err := c.send(ctx, "PUT", c.tradePath(tradeSpecifier, "/close"), body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+/*
+struct for unmarshalling the response from updating a trade's client extensions
+*/
+type TradeClientExtensionsResponse struct {
+	TradeClientExtensionsModifyTransaction *TradeClientExtensionsModifyTransaction `json:"tradeClientExtensionsModifyTransaction"`
+	RelatedTransactionIDs                  []string                                `json:"relatedTransactionIDs"`
+	LastTransactionID                      string                                  `json:"lastTransactionID"`
+}
+
+/*
+SetTradeClientExtensions method will update the client extensions for a trade
+in the client's account. Do not add, update, or delete these if your account
+is associated with MT4.
+
+endpoint: /v3/accounts/{accountID}/trades/{tradeSpecifier}/clientExtensions
+*/
+func (c *Client) SetTradeClientExtensions(ctx context.Context, tradeSpecifier string, extensions *ClientExtensions) (*TradeClientExtensionsResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	body := struct {
+		ClientExtensions *ClientExtensions `json:"clientExtensions"`
+	}{extensions}
+
+	var response TradeClientExtensionsResponse
+	if err := c.send(ctx, "PUT", c.tradePath(tradeSpecifier, "/clientExtensions"), body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+/*
+TradeOrdersRequest holds a trade's dependent orders to create, replace or
+cancel with SetTradeOrders. Orders which are nil and not cancelled are
+left as they are.
+*/
+type TradeOrdersRequest struct {
+	TakeProfit         *TakeProfitDetails
+	StopLoss           *StopLossDetails
+	GuaranteedStopLoss *GuaranteedStopLossDetails
+	TrailingStopLoss   *TrailingStopLossDetails
+
+	CancelTakeProfit         bool
+	CancelStopLoss           bool
+	CancelGuaranteedStopLoss bool
+	CancelTrailingStopLoss   bool
+}
+
+// MarshalJSON sends a cancelled order as null, which is how Oanda tells
+// cancelling an order apart from leaving it unchanged.
+func (r TradeOrdersRequest) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any)
+	add := func(name string, details any, isNil, cancel bool) {
+		switch {
+		case cancel:
+			fields[name] = nil
+		case !isNil:
+			fields[name] = details
+		}
+	}
+	add("takeProfit", r.TakeProfit, r.TakeProfit == nil, r.CancelTakeProfit)
+	add("stopLoss", r.StopLoss, r.StopLoss == nil, r.CancelStopLoss)
+	add("guaranteedStopLoss", r.GuaranteedStopLoss, r.GuaranteedStopLoss == nil, r.CancelGuaranteedStopLoss)
+	add("trailingStopLoss", r.TrailingStopLoss, r.TrailingStopLoss == nil, r.CancelTrailingStopLoss)
+	return json.Marshal(fields)
+}
+
+/*
+struct for unmarshalling the response from SetTradeOrders, for each dependent
+order the transactions which cancelled the old order, created the new one
+and, if it was filled immediately, filled it
+*/
+type TradeOrdersResponse struct {
+	TakeProfitOrderCancelTransaction                *OrderCancelTransaction             `json:"takeProfitOrderCancelTransaction,omitempty"`
+	TakeProfitOrderTransaction                      *TakeProfitOrderTransaction         `json:"takeProfitOrderTransaction,omitempty"`
+	TakeProfitOrderFillTransaction                  *OrderFillTransaction               `json:"takeProfitOrderFillTransaction,omitempty"`
+	TakeProfitOrderCreatedCancelTransaction         *OrderCancelTransaction             `json:"takeProfitOrderCreatedCancelTransaction,omitempty"`
+	StopLossOrderCancelTransaction                  *OrderCancelTransaction             `json:"stopLossOrderCancelTransaction,omitempty"`
+	StopLossOrderTransaction                        *StopLossOrderTransaction           `json:"stopLossOrderTransaction,omitempty"`
+	StopLossOrderFillTransaction                    *OrderFillTransaction               `json:"stopLossOrderFillTransaction,omitempty"`
+	StopLossOrderCreatedCancelTransaction           *OrderCancelTransaction             `json:"stopLossOrderCreatedCancelTransaction,omitempty"`
+	GuaranteedStopLossOrderCancelTransaction        *OrderCancelTransaction             `json:"guaranteedStopLossOrderCancelTransaction,omitempty"`
+	GuaranteedStopLossOrderTransaction              *GuaranteedStopLossOrderTransaction `json:"guaranteedStopLossOrderTransaction,omitempty"`
+	GuaranteedStopLossOrderFillTransaction          *OrderFillTransaction               `json:"guaranteedStopLossOrderFillTransaction,omitempty"`
+	GuaranteedStopLossOrderCreatedCancelTransaction *OrderCancelTransaction             `json:"guaranteedStopLossOrderCreatedCancelTransaction,omitempty"`
+	TrailingStopLossOrderCancelTransaction          *OrderCancelTransaction             `json:"trailingStopLossOrderCancelTransaction,omitempty"`
+	TrailingStopLossOrderTransaction                *TrailingStopLossOrderTransaction   `json:"trailingStopLossOrderTransaction,omitempty"`
+	RelatedTransactionIDs                           []string                            `json:"relatedTransactionIDs"`
+	LastTransactionID                               string                              `json:"lastTransactionID"`
+}
+
+/*
+SetTradeOrders method will create, replace or cancel a trade's take profit,
+stop loss, guaranteed stop loss and trailing stop loss orders.
+
+endpoint: /v3/accounts/{accountID}/trades/{tradeSpecifier}/orders
+*/
+func (c *Client) SetTradeOrders(ctx context.Context, tradeSpecifier string, req TradeOrdersRequest) (*TradeOrdersResponse, error) {
+	if err := c.checkTrading(); err != nil {
+		return nil, err
+	}
+
+	var response TradeOrdersResponse
+	if err := c.send(ctx, "PUT", c.tradePath(tradeSpecifier, "/orders"), req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,161 @@
+package oanda_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestGetTrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/trades" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if want := "ids=7%2C9&state=CLOSED"; r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"trades":[{
+	"id": "7", "instrument": "EUR_USD", "price": "1.08", "openTime": "2024-01-02T10:00:00.000000000Z",
+	"state": "OPEN", "initialUnits": "100", "currentUnits": "100", "realizedPL": "0.0000",
+	"unrealizedPL": "0.2500", "marginUsed": "2.1600", "financing": "0.0000",
+	"clientExtensions": {"id": "my-trade"},
+	"takeProfitOrder": {"id": "8", "type": "TAKE_PROFIT", "state": "PENDING", "tradeID": "7", "price": "1.1"}
+}],"lastTransactionID":"9"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	list, err := client.GetTrades(context.Background(), &oanda.TradesRequest{
+		IDs:   []string{"7", "9"},
+		State: oanda.TradeStateClosed,
+	})
+	if err != nil {
+		t.Fatalf("GetTrades() produced an error: %v", err)
+	}
+	if len(list.Trades) != 1 {
+		t.Fatalf("GetTrades() should return 1 trade but returned: %d", len(list.Trades))
+	}
+
+	trade := list.Trades[0]
+	if trade.UnrealizedPL != "0.2500" || trade.MarginUsed != "2.1600" || trade.ClientExtensions.ID != "my-trade" {
+		t.Errorf("trade was not decoded: %+v", trade)
+	}
+	if trade.TakeProfitOrder == nil || trade.TakeProfitOrder.Price != "1.1" || trade.TakeProfitOrder.ID != "8" {
+		t.Errorf("trade should have a take profit order at 1.1 but has: %+v", trade.TakeProfitOrder)
+	}
+	if trade.StopLossOrder != nil {
+		t.Errorf("trade should not have a stop loss order but has: %+v", trade.StopLossOrder)
+	}
+
+	data, err := json.Marshal(trade)
+	if err != nil {
+		t.Fatalf("json.Marshal() produced an error: %v", err)
+	}
+	if strings.Contains(string(data), "closeTime") {
+		t.Errorf("an open trade should not have a closeTime but json is: %s", data)
+	}
+}
+
+func TestCloseTrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/v3/accounts/101-001-1234567-001/trades/@my-trade/close" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %v", err)
+		}
+		if body["units"] != "ALL" {
+			t.Errorf("units should be ALL but is: %q", body["units"])
+		}
+
+		fmt.Fprint(w, `{
+	"orderCreateTransaction": {"id": "10", "type": "MARKET_ORDER", "instrument": "EUR_USD", "units": "-100", "reason": "TRADE_CLOSE", "tradeClose": {"tradeID": "7", "clientTradeID": "my-trade", "units": "ALL"}},
+	"orderFillTransaction": {"id": "11", "type": "ORDER_FILL", "orderID": "10", "units": "-100", "tradesClosed": [{"tradeID": "7", "units": "-100", "realizedPL": "0.2500"}]},
+	"relatedTransactionIDs": ["10", "11"],
+	"lastTransactionID": "11"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	response, err := client.CloseTrade(context.Background(), oanda.ClientTradeSpecifier("my-trade"), "ALL")
+	if err != nil {
+		t.Fatalf("CloseTrade() produced an error: %v", err)
+	}
+
+	create, ok := response.OrderCreateTransaction.(*oanda.MarketOrderTransaction)
+	if !ok || create.TradeClose == nil || create.TradeClose.TradeID != "7" {
+		t.Errorf("OrderCreateTransaction should be a market order closing trade 7 but is: %+v", response.OrderCreateTransaction)
+	}
+	if fill := response.OrderFillTransaction; fill == nil || len(fill.TradesClosed) != 1 || fill.TradesClosed[0].RealizedPL != "0.2500" {
+		t.Errorf("OrderFillTransaction should close trade 7 but is: %+v", response.OrderFillTransaction)
+	}
+}
+
+func TestCloseTradeLiveTradingDisabled(t *testing.T) {
+	client := oanda.NewClient(oanda.WithEnvironment(oanda.Live))
+
+	_, err := client.CloseTrade(context.Background(), "7", "ALL")
+	if !errors.Is(err, oanda.ErrLiveTradingDisabled) {
+		t.Fatalf("CloseTrade() should fail with oanda.ErrLiveTradingDisabled but returned: %v", err)
+	}
+}
+
+func TestSetTradeOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request body: %v", err)
+		}
+		if string(body["stopLoss"]) != "null" {
+			t.Errorf("stopLoss should be null to cancel it but is: %s", body["stopLoss"])
+		}
+		if tp, ok := body["takeProfit"]; !ok || string(tp) != `{"price":"1.1"}` {
+			t.Errorf("takeProfit should be set at 1.1 but is: %s", tp)
+		}
+		if _, ok := body["trailingStopLoss"]; ok {
+			t.Error("trailingStopLoss should be left out when it is unchanged")
+		}
+
+		fmt.Fprint(w, `{
+	"takeProfitOrderTransaction": {"id": "12", "type": "TAKE_PROFIT_ORDER", "tradeID": "7", "price": "1.1", "reason": "CLIENT_ORDER"},
+	"stopLossOrderCancelTransaction": {"id": "13", "type": "ORDER_CANCEL", "orderID": "9", "reason": "CLIENT_REQUEST"},
+	"relatedTransactionIDs": ["12", "13"],
+	"lastTransactionID": "13"
+}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	response, err := client.SetTradeOrders(context.Background(), "7", oanda.TradeOrdersRequest{
+		TakeProfit:     &oanda.TakeProfitDetails{Price: "1.1"},
+		CancelStopLoss: true,
+	})
+	if err != nil {
+		t.Fatalf("SetTradeOrders() produced an error: %v", err)
+	}
+	if tp := response.TakeProfitOrderTransaction; tp == nil || tp.TradeID != "7" || tp.Price != "1.1" {
+		t.Errorf("TakeProfitOrderTransaction should be for trade 7 at 1.1 but is: %+v", tp)
+	}
+	if sl := response.StopLossOrderCancelTransaction; sl == nil || sl.OrderID != "9" {
+		t.Errorf("StopLossOrderCancelTransaction should cancel order 9 but is: %+v", sl)
+	}
+}
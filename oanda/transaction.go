@@ -0,0 +1,918 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Transaction is implemented by every transaction type returned from Oanda's
+[Transaction Endpoints]. Use a type switch to get the concrete type:
+
+	switch tx := tx.(type) {
+	case *oanda.OrderFillTransaction:
+		fmt.Println("filled", tx.Units, tx.Instrument, "at", tx.Price)
+	case *oanda.MarginCallEnterTransaction:
+		fmt.Println("margin call!")
+	}
+
+Transaction types this package does not know about are decoded
+as *UnknownTransaction.
+
+[Transaction Endpoints]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+type Transaction interface {
+	// Base returns the fields shared by every transaction.
+	Base() *TransactionBase
+}
+
+/*
+embedded struct with the fields shared by every Transaction
+*/
+type TransactionBase struct {
+	ID        string `json:"id"`
+	Time      Time   `json:"time"`
+	UserID    int    `json:"userID"`
+	AccountID string `json:"accountID"`
+	BatchID   string `json:"batchID"`
+	RequestID string `json:"requestID,omitempty"`
+	Type      string `json:"type"`
+}
+
+func (t *TransactionBase) Base() *TransactionBase {
+	return t
+}
+
+/*
+struct for the client extensions which can be attached to orders and trades,
+do not set, modify, or delete these if your account is associated with MT4.
+*/
+type ClientExtensions struct {
+	ID      string `json:"id,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+/*
+struct for a CREATE transaction, created when an account is created
+*/
+type CreateTransaction struct {
+	TransactionBase
+	DivisionID    int    `json:"divisionID"`
+	SiteID        int    `json:"siteID"`
+	AccountUserID int    `json:"accountUserID"`
+	AccountNumber int    `json:"accountNumber"`
+	HomeCurrency  string `json:"homeCurrency"`
+}
+
+/*
+struct for a CLOSE transaction, created when an account is closed
+*/
+type CloseTransaction struct {
+	TransactionBase
+}
+
+/*
+struct for a REOPEN transaction, created when a closed account is reopened
+*/
+type ReopenTransaction struct {
+	TransactionBase
+}
+
+/*
+struct for a CLIENT_CONFIGURE transaction, created when an account's alias
+or margin rate is configured
+*/
+type ClientConfigureTransaction struct {
+	TransactionBase
+	Alias      string `json:"alias,omitempty"`
+	MarginRate string `json:"marginRate,omitempty"`
+}
+
+/*
+struct for a CLIENT_CONFIGURE_REJECT transaction, created when an account
+cannot be configured
+*/
+type ClientConfigureRejectTransaction struct {
+	TransactionBase
+	Alias        string `json:"alias,omitempty"`
+	MarginRate   string `json:"marginRate,omitempty"`
+	RejectReason string `json:"rejectReason"`
+}
+
+/*
+struct for a TRANSFER_FUNDS transaction, created when funds are deposited
+to or withdrawn from an account
+*/
+type TransferFundsTransaction struct {
+	TransactionBase
+	Amount         string `json:"amount"`
+	FundingReason  string `json:"fundingReason"`
+	Comment        string `json:"comment,omitempty"`
+	AccountBalance string `json:"accountBalance"`
+}
+
+/*
+struct for a TRANSFER_FUNDS_REJECT transaction, created when funds cannot
+be deposited to or withdrawn from an account
+*/
+type TransferFundsRejectTransaction struct {
+	TransactionBase
+	Amount        string `json:"amount"`
+	FundingReason string `json:"fundingReason"`
+	Comment       string `json:"comment,omitempty"`
+	RejectReason  string `json:"rejectReason"`
+}
+
+/*
+embedded struct for OrderFillTransaction, a trade opened by the fill
+*/
+type TradeOpen struct {
+	TradeID                     string            `json:"tradeID"`
+	Units                       string            `json:"units"`
+	Price                       string            `json:"price"`
+	GuaranteedExecutionFee      string            `json:"guaranteedExecutionFee,omitempty"`
+	QuoteGuaranteedExecutionFee string            `json:"quoteGuaranteedExecutionFee,omitempty"`
+	ClientExtensions            *ClientExtensions `json:"clientExtensions,omitempty"`
+	HalfSpreadCost              string            `json:"halfSpreadCost,omitempty"`
+	InitialMarginRequired       string            `json:"initialMarginRequired,omitempty"`
+}
+
+/*
+embedded struct for OrderFillTransaction, a trade closed or reduced by the fill
+*/
+type TradeReduce struct {
+	TradeID                     string `json:"tradeID"`
+	Units                       string `json:"units"`
+	Price                       string `json:"price"`
+	RealizedPL                  string `json:"realizedPL"`
+	Financing                   string `json:"financing"`
+	BaseFinancing               string `json:"baseFinancing,omitempty"`
+	QuoteFinancing              string `json:"quoteFinancing,omitempty"`
+	FinancingRate               string `json:"financingRate,omitempty"`
+	GuaranteedExecutionFee      string `json:"guaranteedExecutionFee,omitempty"`
+	QuoteGuaranteedExecutionFee string `json:"quoteGuaranteedExecutionFee,omitempty"`
+	HalfSpreadCost              string `json:"halfSpreadCost,omitempty"`
+}
+
+/*
+struct for an ORDER_FILL transaction, created when an order is filled. Stop outs
+are fills with a Reason such as "STOP_LOSS_ORDER" or "MARKET_ORDER_MARGIN_CLOSEOUT".
+*/
+type OrderFillTransaction struct {
+	TransactionBase
+	OrderID                     string        `json:"orderID"`
+	ClientOrderID               string        `json:"clientOrderID,omitempty"`
+	Instrument                  string        `json:"instrument"`
+	Units                       string        `json:"units"`
+	Price                       string        `json:"price"`
+	FullVWAP                    string        `json:"fullVWAP,omitempty"`
+	Reason                      string        `json:"reason"`
+	PL                          string        `json:"pl"`
+	QuotePL                     string        `json:"quotePL,omitempty"`
+	Financing                   string        `json:"financing"`
+	BaseFinancing               string        `json:"baseFinancing,omitempty"`
+	QuoteFinancing              string        `json:"quoteFinancing,omitempty"`
+	Commission                  string        `json:"commission"`
+	GuaranteedExecutionFee      string        `json:"guaranteedExecutionFee"`
+	QuoteGuaranteedExecutionFee string        `json:"quoteGuaranteedExecutionFee,omitempty"`
+	AccountBalance              string        `json:"accountBalance"`
+	TradeOpened                 *TradeOpen    `json:"tradeOpened,omitempty"`
+	TradesClosed                []TradeReduce `json:"tradesClosed,omitempty"`
+	TradeReduced                *TradeReduce  `json:"tradeReduced,omitempty"`
+	HalfSpreadCost              string        `json:"halfSpreadCost,omitempty"`
+}
+
+/*
+struct for an ORDER_CANCEL transaction, created when an order is cancelled
+*/
+type OrderCancelTransaction struct {
+	TransactionBase
+	OrderID           string `json:"orderID"`
+	ClientOrderID     string `json:"clientOrderID,omitempty"`
+	Reason            string `json:"reason"`
+	ReplacedByOrderID string `json:"replacedByOrderID,omitempty"`
+}
+
+/*
+struct for an ORDER_CANCEL_REJECT transaction, created when an order
+cannot be cancelled
+*/
+type OrderCancelRejectTransaction struct {
+	TransactionBase
+	OrderID       string `json:"orderID"`
+	ClientOrderID string `json:"clientOrderID,omitempty"`
+	RejectReason  string `json:"rejectReason"`
+}
+
+/*
+struct for an ORDER_CLIENT_EXTENSIONS_MODIFY transaction, created when an
+order's client extensions are updated
+*/
+type OrderClientExtensionsModifyTransaction struct {
+	TransactionBase
+	OrderID                     string            `json:"orderID"`
+	ClientOrderID               string            `json:"clientOrderID,omitempty"`
+	ClientExtensionsModify      *ClientExtensions `json:"clientExtensionsModify,omitempty"`
+	TradeClientExtensionsModify *ClientExtensions `json:"tradeClientExtensionsModify,omitempty"`
+}
+
+/*
+struct for an ORDER_CLIENT_EXTENSIONS_MODIFY_REJECT transaction, created when
+an order's client extensions cannot be updated
+*/
+type OrderClientExtensionsModifyRejectTransaction struct {
+	TransactionBase
+	OrderID                     string            `json:"orderID"`
+	ClientOrderID               string            `json:"clientOrderID,omitempty"`
+	ClientExtensionsModify      *ClientExtensions `json:"clientExtensionsModify,omitempty"`
+	TradeClientExtensionsModify *ClientExtensions `json:"tradeClientExtensionsModify,omitempty"`
+	RejectReason                string            `json:"rejectReason"`
+}
+
+/*
+struct for a TRADE_CLIENT_EXTENSIONS_MODIFY transaction, created when a
+trade's client extensions are updated
+*/
+type TradeClientExtensionsModifyTransaction struct {
+	TransactionBase
+	TradeID                     string            `json:"tradeID"`
+	ClientTradeID               string            `json:"clientTradeID,omitempty"`
+	TradeClientExtensionsModify *ClientExtensions `json:"tradeClientExtensionsModify,omitempty"`
+}
+
+/*
+struct for a TRADE_CLIENT_EXTENSIONS_MODIFY_REJECT transaction, created when
+a trade's client extensions cannot be updated
+*/
+type TradeClientExtensionsModifyRejectTransaction struct {
+	TransactionBase
+	TradeID                     string            `json:"tradeID"`
+	ClientTradeID               string            `json:"clientTradeID,omitempty"`
+	TradeClientExtensionsModify *ClientExtensions `json:"tradeClientExtensionsModify,omitempty"`
+	RejectReason                string            `json:"rejectReason"`
+}
+
+/*
+struct for a MARGIN_CALL_ENTER transaction, created when an account enters
+the margin call state
+*/
+type MarginCallEnterTransaction struct {
+	TransactionBase
+}
+
+/*
+struct for a MARGIN_CALL_EXTEND transaction, created when the margin call
+state for an account has been extended
+*/
+type MarginCallExtendTransaction struct {
+	TransactionBase
+	ExtensionNumber int `json:"extensionNumber"`
+}
+
+/*
+struct for a MARGIN_CALL_EXIT transaction, created when an account leaves
+the margin call state
+*/
+type MarginCallExitTransaction struct {
+	TransactionBase
+}
+
+/*
+struct for a DELAYED_TRADE_CLOSURE transaction, created when trades are closed
+once their instrument becomes tradeable (see TradeStateCloseWhenTradeable)
+*/
+type DelayedTradeClosureTransaction struct {
+	TransactionBase
+	Reason   string `json:"reason"`
+	TradeIDs string `json:"tradeIDs"`
+}
+
+/*
+struct for a RESET_RESETTABLE_PL transaction, created when an account's
+resettable PL is reset
+*/
+type ResetResettablePLTransaction struct {
+	TransactionBase
+}
+
+/*
+embedded struct for HomeConversionFactors, multiply an amount by Factor
+to convert it into the account's home currency
+*/
+type ConversionFactor struct {
+	Factor string `json:"factor"`
+}
+
+/*
+embedded struct for the factors used to convert gains and losses in an
+instrument's quote and base currencies into the account's home currency
+*/
+type HomeConversionFactors struct {
+	GainQuoteHome ConversionFactor `json:"gainQuoteHome"`
+	LossQuoteHome ConversionFactor `json:"lossQuoteHome"`
+	GainBaseHome  ConversionFactor `json:"gainBaseHome"`
+	LossBaseHome  ConversionFactor `json:"lossBaseHome"`
+}
+
+/*
+embedded struct for DividendAdjustmentTransaction, the dividend paid or
+collected for a single open trade
+*/
+type OpenTradeDividendAdjustment struct {
+	TradeID                 string `json:"tradeID"`
+	DividendAdjustment      string `json:"dividendAdjustment"`
+	QuoteDividendAdjustment string `json:"quoteDividendAdjustment,omitempty"`
+}
+
+/*
+struct for a DIVIDEND_ADJUSTMENT transaction, created when dividends are
+paid or collected for the open trades in an instrument
+*/
+type DividendAdjustmentTransaction struct {
+	TransactionBase
+	Instrument                   string                        `json:"instrument"`
+	DividendAdjustment           string                        `json:"dividendAdjustment"`
+	QuoteDividendAdjustment      string                        `json:"quoteDividendAdjustment,omitempty"`
+	HomeConversionFactors        *HomeConversionFactors        `json:"homeConversionFactors,omitempty"`
+	AccountBalance               string                        `json:"accountBalance"`
+	OpenTradeDividendAdjustments []OpenTradeDividendAdjustment `json:"openTradeDividendAdjustments,omitempty"`
+}
+
+/*
+embedded struct for DailyFinancingTransaction, financing paid or collected
+for a single instrument
+*/
+type PositionFinancing struct {
+	Instrument           string `json:"instrument"`
+	Financing            string `json:"financing"`
+	BaseFinancing        string `json:"baseFinancing,omitempty"`
+	QuoteFinancing       string `json:"quoteFinancing,omitempty"`
+	AccountFinancingMode string `json:"accountFinancingMode,omitempty"`
+}
+
+/*
+struct for a DAILY_FINANCING transaction, created when daily financing is
+applied to an account
+*/
+type DailyFinancingTransaction struct {
+	TransactionBase
+	Financing            string              `json:"financing"`
+	AccountBalance       string              `json:"accountBalance"`
+	AccountFinancingMode string              `json:"accountFinancingMode,omitempty"`
+	PositionFinancings   []PositionFinancing `json:"positionFinancings,omitempty"`
+}
+
+/*
+embedded struct for MarketOrderTransaction, the trade a market order was
+created to close
+*/
+type MarketOrderTradeClose struct {
+	TradeID       string `json:"tradeID"`
+	ClientTradeID string `json:"clientTradeID,omitempty"`
+	Units         string `json:"units"`
+}
+
+/*
+embedded struct for MarketOrderTransaction, the side of a position a market
+order was created to close
+*/
+type MarketOrderPositionCloseout struct {
+	Instrument string `json:"instrument"`
+	Units      string `json:"units"`
+}
+
+/*
+struct for a MARKET_ORDER transaction, created when a market order is requested
+*/
+type MarketOrderTransaction struct {
+	TransactionBase
+	MarketOrderRequest
+	TradeClose            *MarketOrderTradeClose       `json:"tradeClose,omitempty"`
+	LongPositionCloseout  *MarketOrderPositionCloseout `json:"longPositionCloseout,omitempty"`
+	ShortPositionCloseout *MarketOrderPositionCloseout `json:"shortPositionCloseout,omitempty"`
+	Reason                string                       `json:"reason"`
+}
+
+/*
+struct for a MARKET_ORDER_REJECT transaction, created when a market order is rejected
+*/
+type MarketOrderRejectTransaction struct {
+	TransactionBase
+	MarketOrderRequest
+	TradeClose            *MarketOrderTradeClose       `json:"tradeClose,omitempty"`
+	LongPositionCloseout  *MarketOrderPositionCloseout `json:"longPositionCloseout,omitempty"`
+	ShortPositionCloseout *MarketOrderPositionCloseout `json:"shortPositionCloseout,omitempty"`
+	Reason                string                       `json:"reason"`
+	RejectReason          string                       `json:"rejectReason"`
+}
+
+/*
+struct for a FIXED_PRICE_ORDER transaction, created by Oanda to fill an order
+at a fixed price, i.e. when an account is migrated
+*/
+type FixedPriceOrderTransaction struct {
+	TransactionBase
+	Instrument       string            `json:"instrument"`
+	Units            string            `json:"units"`
+	Price            string            `json:"price"`
+	PositionFill     string            `json:"positionFill,omitempty"`
+	TradeState       string            `json:"tradeState,omitempty"`
+	Reason           string            `json:"reason"`
+	ClientExtensions *ClientExtensions `json:"clientExtensions,omitempty"`
+	OnFill
+}
+
+/*
+struct for a LIMIT_ORDER transaction, created when a limit order is requested
+*/
+type LimitOrderTransaction struct {
+	TransactionBase
+	LimitOrderRequest
+	Reason                  string `json:"reason"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a LIMIT_ORDER_REJECT transaction, created when a limit order is rejected
+*/
+type LimitOrderRejectTransaction struct {
+	TransactionBase
+	LimitOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a STOP_ORDER transaction, created when a stop order is requested
+*/
+type StopOrderTransaction struct {
+	TransactionBase
+	StopOrderRequest
+	Reason                  string `json:"reason"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a STOP_ORDER_REJECT transaction, created when a stop order is rejected
+*/
+type StopOrderRejectTransaction struct {
+	TransactionBase
+	StopOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a MARKET_IF_TOUCHED_ORDER transaction, created when a market if touched order is requested
+*/
+type MarketIfTouchedOrderTransaction struct {
+	TransactionBase
+	MarketIfTouchedOrderRequest
+	Reason                  string `json:"reason"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a MARKET_IF_TOUCHED_ORDER_REJECT transaction, created when a market if touched order is rejected
+*/
+type MarketIfTouchedOrderRejectTransaction struct {
+	TransactionBase
+	MarketIfTouchedOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a TAKE_PROFIT_ORDER transaction, created when a take profit order is requested
+*/
+type TakeProfitOrderTransaction struct {
+	TransactionBase
+	TakeProfitOrderRequest
+	Reason                  string `json:"reason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a TAKE_PROFIT_ORDER_REJECT transaction, created when a take profit order is rejected
+*/
+type TakeProfitOrderRejectTransaction struct {
+	TransactionBase
+	TakeProfitOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a STOP_LOSS_ORDER transaction, created when a stop loss order is requested
+*/
+type StopLossOrderTransaction struct {
+	TransactionBase
+	StopLossOrderRequest
+	Reason                  string `json:"reason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a STOP_LOSS_ORDER_REJECT transaction, created when a stop loss order is rejected
+*/
+type StopLossOrderRejectTransaction struct {
+	TransactionBase
+	StopLossOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a GUARANTEED_STOP_LOSS_ORDER transaction, created when a guaranteed stop loss order is requested
+*/
+type GuaranteedStopLossOrderTransaction struct {
+	TransactionBase
+	GuaranteedStopLossOrderRequest
+	Reason                  string `json:"reason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a GUARANTEED_STOP_LOSS_ORDER_REJECT transaction, created when a guaranteed stop loss order is rejected
+*/
+type GuaranteedStopLossOrderRejectTransaction struct {
+	TransactionBase
+	GuaranteedStopLossOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for a TRAILING_STOP_LOSS_ORDER transaction, created when a trailing stop loss order is requested
+*/
+type TrailingStopLossOrderTransaction struct {
+	TransactionBase
+	TrailingStopLossOrderRequest
+	Reason                  string `json:"reason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	ReplacesOrderID         string `json:"replacesOrderID,omitempty"`
+	CancellingTransactionID string `json:"cancellingTransactionID,omitempty"`
+}
+
+/*
+struct for a TRAILING_STOP_LOSS_ORDER_REJECT transaction, created when a trailing stop loss order is rejected
+*/
+type TrailingStopLossOrderRejectTransaction struct {
+	TransactionBase
+	TrailingStopLossOrderRequest
+	Reason                  string `json:"reason"`
+	RejectReason            string `json:"rejectReason"`
+	OrderFillTransactionID  string `json:"orderFillTransactionID,omitempty"`
+	IntendedReplacesOrderID string `json:"intendedReplacesOrderID,omitempty"`
+}
+
+/*
+struct for any transaction type this package does not decode, Raw holds
+the complete json
+*/
+type UnknownTransaction struct {
+	TransactionBase
+	Raw json.RawMessage `json:"-"`
+}
+
+// transactionTypes maps the "type" field of a transaction to a
+// constructor for its concrete type.
+var transactionTypes = map[string]func() Transaction{
+	"CREATE":                                func() Transaction { return &CreateTransaction{} },
+	"CLOSE":                                 func() Transaction { return &CloseTransaction{} },
+	"REOPEN":                                func() Transaction { return &ReopenTransaction{} },
+	"CLIENT_CONFIGURE":                      func() Transaction { return &ClientConfigureTransaction{} },
+	"CLIENT_CONFIGURE_REJECT":               func() Transaction { return &ClientConfigureRejectTransaction{} },
+	"TRANSFER_FUNDS":                        func() Transaction { return &TransferFundsTransaction{} },
+	"TRANSFER_FUNDS_REJECT":                 func() Transaction { return &TransferFundsRejectTransaction{} },
+	"FIXED_PRICE_ORDER":                     func() Transaction { return &FixedPriceOrderTransaction{} },
+	"MARKET_ORDER":                          func() Transaction { return &MarketOrderTransaction{} },
+	"MARKET_ORDER_REJECT":                   func() Transaction { return &MarketOrderRejectTransaction{} },
+	"LIMIT_ORDER":                           func() Transaction { return &LimitOrderTransaction{} },
+	"LIMIT_ORDER_REJECT":                    func() Transaction { return &LimitOrderRejectTransaction{} },
+	"STOP_ORDER":                            func() Transaction { return &StopOrderTransaction{} },
+	"STOP_ORDER_REJECT":                     func() Transaction { return &StopOrderRejectTransaction{} },
+	"MARKET_IF_TOUCHED_ORDER":               func() Transaction { return &MarketIfTouchedOrderTransaction{} },
+	"MARKET_IF_TOUCHED_ORDER_REJECT":        func() Transaction { return &MarketIfTouchedOrderRejectTransaction{} },
+	"TAKE_PROFIT_ORDER":                     func() Transaction { return &TakeProfitOrderTransaction{} },
+	"TAKE_PROFIT_ORDER_REJECT":              func() Transaction { return &TakeProfitOrderRejectTransaction{} },
+	"STOP_LOSS_ORDER":                       func() Transaction { return &StopLossOrderTransaction{} },
+	"STOP_LOSS_ORDER_REJECT":                func() Transaction { return &StopLossOrderRejectTransaction{} },
+	"GUARANTEED_STOP_LOSS_ORDER":            func() Transaction { return &GuaranteedStopLossOrderTransaction{} },
+	"GUARANTEED_STOP_LOSS_ORDER_REJECT":     func() Transaction { return &GuaranteedStopLossOrderRejectTransaction{} },
+	"TRAILING_STOP_LOSS_ORDER":              func() Transaction { return &TrailingStopLossOrderTransaction{} },
+	"TRAILING_STOP_LOSS_ORDER_REJECT":       func() Transaction { return &TrailingStopLossOrderRejectTransaction{} },
+	"ORDER_FILL":                            func() Transaction { return &OrderFillTransaction{} },
+	"ORDER_CANCEL":                          func() Transaction { return &OrderCancelTransaction{} },
+	"ORDER_CANCEL_REJECT":                   func() Transaction { return &OrderCancelRejectTransaction{} },
+	"ORDER_CLIENT_EXTENSIONS_MODIFY":        func() Transaction { return &OrderClientExtensionsModifyTransaction{} },
+	"ORDER_CLIENT_EXTENSIONS_MODIFY_REJECT": func() Transaction { return &OrderClientExtensionsModifyRejectTransaction{} },
+	"TRADE_CLIENT_EXTENSIONS_MODIFY":        func() Transaction { return &TradeClientExtensionsModifyTransaction{} },
+	"TRADE_CLIENT_EXTENSIONS_MODIFY_REJECT": func() Transaction { return &TradeClientExtensionsModifyRejectTransaction{} },
+	"MARGIN_CALL_ENTER":                     func() Transaction { return &MarginCallEnterTransaction{} },
+	"MARGIN_CALL_EXTEND":                    func() Transaction { return &MarginCallExtendTransaction{} },
+	"MARGIN_CALL_EXIT":                      func() Transaction { return &MarginCallExitTransaction{} },
+	"DAILY_FINANCING":                       func() Transaction { return &DailyFinancingTransaction{} },
+	"DELAYED_TRADE_CLOSURE":                 func() Transaction { return &DelayedTradeClosureTransaction{} },
+	"RESET_RESETTABLE_PL":                   func() Transaction { return &ResetResettablePLTransaction{} },
+	"DIVIDEND_ADJUSTMENT":                   func() Transaction { return &DividendAdjustmentTransaction{} },
+}
+
+// UnmarshalTransaction decodes a single transaction into its concrete type
+// based on the "type" field.
+func UnmarshalTransaction(data []byte) (Transaction, error) {
+	var base TransactionBase
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("error unmarshaling json: %s", err.Error())
+	}
+
+	newTransaction, ok := transactionTypes[base.Type]
+	if !ok {
+		return &UnknownTransaction{TransactionBase: base, Raw: data}, nil
+	}
+
+	tx := newTransaction()
+	if err := json.Unmarshal(data, tx); err != nil {
+		return nil, fmt.Errorf("error unmarshaling %s transaction: %s", base.Type, err.Error())
+	}
+	return tx, nil
+}
+
+// unmarshalOptionalTransaction is the same as UnmarshalTransaction but
+// returns nil for an empty or null field.
+func unmarshalOptionalTransaction(data json.RawMessage) (Transaction, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	return UnmarshalTransaction(data)
+}
+
+// unmarshalTransactions decodes each transaction into its concrete type.
+func unmarshalTransactions(raw []json.RawMessage) ([]Transaction, error) {
+	transactions := make([]Transaction, 0, len(raw))
+	for _, data := range raw {
+		tx, err := UnmarshalTransaction(data)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+/*
+struct for unmarshalling a list of transactions from [Transaction Endpoints]
+
+[Transaction Endpoints]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+type TransactionList struct {
+	Transactions      []Transaction `json:"-"`
+	LastTransactionID string        `json:"lastTransactionID"`
+}
+
+func (l *TransactionList) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Transactions      []json.RawMessage `json:"transactions"`
+		LastTransactionID string            `json:"lastTransactionID"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	transactions, err := unmarshalTransactions(raw.Transactions)
+	if err != nil {
+		return err
+	}
+
+	l.Transactions = transactions
+	l.LastTransactionID = raw.LastTransactionID
+	return nil
+}
+
+/*
+GetTransactionsSinceID method will return every transaction for the client's
+account created after (not including) the given transaction ID.
+
+endpoint: /v3/accounts/{accountID}/transactions/sinceid
+*/
+func (c *Client) GetTransactionsSinceID(ctx context.Context, id string) (*TransactionList, error) {
+	q := url.Values{}
+	q.Add("id", id)
+
+	var list TransactionList
+	if err := c.get(ctx, c.accountPath("/transactions/sinceid"), q, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// TransactionsRequest holds the filters for GetTransactionPages, zero values
+// are not sent.
+type TransactionsRequest struct {
+	// From is the start of the time range, defaults to the account's creation.
+	From time.Time
+	// To is the end of the time range, defaults to now.
+	To time.Time
+	// PageSize is the number of transactions in each page, at most 1000.
+	// Defaults to 100.
+	PageSize int
+	// Type only includes transactions of these types or filter groups,
+	// i.e. "ORDER_FILL" or "FUNDING".
+	Type []string
+}
+
+// query returns the request as url query parameters, with times in format.
+func (r *TransactionsRequest) query(format DatetimeFormat) url.Values {
+	q := url.Values{}
+	if !r.From.IsZero() {
+		q.Add("from", format.format(r.From))
+	}
+	if !r.To.IsZero() {
+		q.Add("to", format.format(r.To))
+	}
+	if r.PageSize > 0 {
+		q.Add("pageSize", strconv.Itoa(r.PageSize))
+	}
+	if len(r.Type) > 0 {
+		q.Add("type", strings.Join(r.Type, ","))
+	}
+	return q
+}
+
+/*
+struct for unmarshalling the pages of transactions in a time range from
+[Transaction Endpoints], each page is the URL of an idrange request
+
+endpoint: /v3/accounts/{accountID}/transactions
+
+[Transaction Endpoints]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+type TransactionPages struct {
+	From              string   `json:"from"`
+	To                string   `json:"to"`
+	PageSize          int      `json:"pageSize"`
+	Type              []string `json:"type,omitempty"`
+	Count             int      `json:"count"`
+	Pages             []string `json:"pages"`
+	LastTransactionID string   `json:"lastTransactionID"`
+}
+
+/*
+GetTransactionPages method will return the pages of transactions for the
+client's account in a time range, a nil request returns every transaction.
+Use GetTransactionPage to fetch a page or GetTransactionsInRange to fetch
+all of them.
+
+endpoint: /v3/accounts/{accountID}/transactions
+*/
+func (c *Client) GetTransactionPages(ctx context.Context, req *TransactionsRequest) (*TransactionPages, error) {
+	if req == nil {
+		req = &TransactionsRequest{}
+	}
+
+	var pages TransactionPages
+	if err := c.get(ctx, c.accountPath("/transactions"), req.query(c.datetimeFormat), &pages); err != nil {
+		return nil, err
+	}
+
+	return &pages, nil
+}
+
+/*
+GetTransactionPage method will return the transactions for one of the page
+URLs returned by GetTransactionPages. Only the path and query of the URL are
+used, the request is sent to the client's REST host.
+*/
+func (c *Client) GetTransactionPage(ctx context.Context, page string) (*TransactionList, error) {
+	u, err := url.Parse(page)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing page url: %s", err.Error())
+	}
+
+	var list TransactionList
+	if err := c.get(ctx, u.Path, u.Query(), &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+/*
+GetTransactionsInRange method will follow every page returned by
+GetTransactionPages and return all of the transactions in the time range,
+in order.
+*/
+func (c *Client) GetTransactionsInRange(ctx context.Context, req *TransactionsRequest) (*TransactionList, error) {
+	pages, err := c.GetTransactionPages(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	all := &TransactionList{
+		Transactions:      make([]Transaction, 0, pages.Count),
+		LastTransactionID: pages.LastTransactionID,
+	}
+	for _, page := range pages.Pages {
+		list, err := c.GetTransactionPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		all.Transactions = append(all.Transactions, list.Transactions...)
+	}
+
+	return all, nil
+}
+
+/*
+struct for unmarshalling a single transaction from [Transaction Endpoints]
+
+endpoint: /v3/accounts/{accountID}/transactions/{transactionID}
+
+[Transaction Endpoints]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+type TransactionDetails struct {
+	Transaction       Transaction `json:"-"`
+	LastTransactionID string      `json:"lastTransactionID"`
+}
+
+func (d *TransactionDetails) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Transaction       json.RawMessage `json:"transaction"`
+		LastTransactionID string          `json:"lastTransactionID"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	tx, err := unmarshalOptionalTransaction(raw.Transaction)
+	if err != nil {
+		return err
+	}
+
+	d.Transaction = tx
+	d.LastTransactionID = raw.LastTransactionID
+	return nil
+}
+
+/*
+GetTransaction method will return a single transaction for the client's
+account, decoded into its concrete type.
+
+endpoint: /v3/accounts/{accountID}/transactions/{transactionID}
+*/
+func (c *Client) GetTransaction(ctx context.Context, id string) (*TransactionDetails, error) {
+	var details TransactionDetails
+	if err := c.get(ctx, c.accountPath("/transactions/"+url.PathEscape(id)), nil, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+/*
+GetTransactionsIDRange method will return the transactions for the client's
+account from one transaction ID to another (both inclusive), optionally only
+those of the given types or filter groups.
+
+endpoint: /v3/accounts/{accountID}/transactions/idrange
+*/
+func (c *Client) GetTransactionsIDRange(ctx context.Context, from, to string, types ...string) (*TransactionList, error) {
+	q := url.Values{}
+	q.Add("from", from)
+	q.Add("to", to)
+	if len(types) > 0 {
+		q.Add("type", strings.Join(types, ","))
+	}
+
+	var list TransactionList
+	if err := c.get(ctx, c.accountPath("/transactions/idrange"), q, &list); err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
@@ -0,0 +1,147 @@
+package oanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// TransactionMessage is sent on the channel returned by SubscribeTransactions,
+// it is either a Transaction, a TransactionHeartbeat or a StreamStateEvent.
+type TransactionMessage interface {
+	transactionMessage()
+}
+
+func (*TransactionBase) transactionMessage()     {}
+func (TransactionHeartbeat) transactionMessage() {}
+func (StreamStateEvent) transactionMessage()     {}
+
+/*
+struct for unmarshalling the heartbeat sent every 5 seconds from Oanda's
+[Transaction - stream endpoint]
+
+[Transaction - stream endpoint]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+type TransactionHeartbeat struct {
+	Type              string `json:"type"`
+	LastTransactionID string `json:"lastTransactionID"`
+	Time              Time   `json:"time"`
+}
+
+// transactionIDAfter reports whether transaction id comes after last,
+// every id comes after an empty last.
+func transactionIDAfter(id, last string) bool {
+	if last == "" {
+		return true
+	}
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return true
+	}
+	j, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return true
+	}
+	return i > j
+}
+
+/*
+SubscribeTransactions method connects to Oanda's [Transaction - stream endpoint]
+for the client's account and sends every transaction, decoded into its
+concrete type (see Transaction), and heartbeat on the returned channel.
+
+The connection is reopened the same way as SubscribePricingReconnect. After
+every reconnect any transactions missed while disconnected are fetched from
+the sinceid endpoint and sent before the stream resumes, so no transaction
+is skipped or sent twice. If sinceID is not empty the same is done when first
+connecting, starting after that transaction.
+
+endpoint: /v3/accounts/{accountID}/transactions/stream
+
+[Transaction - stream endpoint]: https://developer.oanda.com/rest-live-v20/transaction-ep/
+*/
+func (c *Client) SubscribeTransactions(ctx context.Context, sinceID string, opts *ReconnectOptions) (<-chan TransactionMessage, <-chan error) {
+	out := make(chan TransactionMessage)
+	errc := make(chan error, 1)
+
+	send := func(msg TransactionMessage) error {
+		select {
+		case out <- msg:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// lastID is the most recent transaction sent
+	lastID := sinceID
+
+	sendTransaction := func(w *watchdog, tx Transaction) error {
+		id := tx.Base().ID
+		if !transactionIDAfter(id, lastID) {
+			return nil
+		}
+		lastID = id
+		return w.deliver(func() error { return send(tx.(TransactionMessage)) })
+	}
+
+	connect := func(ctx context.Context, w *watchdog) error {
+		connected := func() error {
+			if err := w.connected(); err != nil {
+				return err
+			}
+			if lastID == "" {
+				return nil
+			}
+
+			// back-fill anything created while disconnected
+			var list *TransactionList
+			if err := w.pause(func() (err error) {
+				list, err = c.GetTransactionsSinceID(ctx, lastID)
+				return err
+			}); err != nil {
+				return err
+			}
+			for _, tx := range list.Transactions {
+				if err := sendTransaction(w, tx); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return c.stream(ctx, c.accountPath("/transactions/stream"), nil, connected, func(msgType string, raw json.RawMessage) error {
+			switch msgType {
+			case "HEARTBEAT", "TRANSACTION_HEARTBEAT":
+				var heartbeat TransactionHeartbeat
+				if err := json.Unmarshal(raw, &heartbeat); err != nil {
+					return fmt.Errorf("error unmarshaling json: %s", err.Error())
+				}
+				// resume from here if the first connection drops
+				// before any transaction is received
+				if lastID == "" {
+					lastID = heartbeat.LastTransactionID
+				}
+				return w.deliver(func() error { return send(heartbeat) })
+			default:
+				tx, err := UnmarshalTransaction(raw)
+				if err != nil {
+					return err
+				}
+				return sendTransaction(w, tx)
+			}
+		})
+	}
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		errc <- opts.withDefaults().reconnect(ctx, connect, func(event StreamStateEvent) error {
+			return send(event)
+		})
+	}()
+
+	return out, errc
+}
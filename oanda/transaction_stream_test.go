@@ -0,0 +1,105 @@
+package oanda_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestSubscribeTransactions(t *testing.T) {
+	var connections int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/accounts/101-001-1234567-001/transactions/stream", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			fmt.Fprintln(w, `{"id":"5","type":"ORDER_FILL","orderID":"4","instrument":"EUR_USD","units":"100","price":"1.08","reason":"STOP_LOSS_ORDER"}`)
+			fmt.Fprintln(w, `{"type":"HEARTBEAT","lastTransactionID":"5","time":"2024-07-19T21:00:00.000000000Z"}`)
+			// drop the connection
+			return
+		default:
+			// 7 was already back-filled and must not be sent twice
+			fmt.Fprintln(w, `{"id":"7","type":"MARGIN_CALL_ENTER"}`)
+			fmt.Fprintln(w, `{"id":"8","type":"SOMETHING_NEW"}`)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	})
+	mux.HandleFunc("/v3/accounts/101-001-1234567-001/transactions/sinceid", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("id"); got != "5" {
+			t.Errorf("sinceid should be called with id 5 but was called with: %s", got)
+		}
+		fmt.Fprint(w, `{"transactions":[
+	{"id":"6","type":"DAILY_FINANCING","financing":"-0.05","accountBalance":"99999.95"},
+	{"id":"7","type":"MARGIN_CALL_ENTER"}
+],"lastTransactionID":"7"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithStreamURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, errc := client.SubscribeTransactions(ctx, "", &oanda.ReconnectOptions{
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 20 * time.Millisecond,
+	})
+
+	want := []string{
+		"connected",
+		"ORDER_FILL 5",
+		"heartbeat",
+		"reconnecting",
+		"connected",
+		"DAILY_FINANCING 6",
+		"MARGIN_CALL_ENTER 7",
+		"SOMETHING_NEW 8",
+	}
+	for i, w := range want {
+		var got string
+		switch msg := (<-messages).(type) {
+		case oanda.StreamStateEvent:
+			got = msg.State.String()
+		case oanda.TransactionHeartbeat:
+			got = "heartbeat"
+		case oanda.Transaction:
+			got = msg.Base().Type + " " + msg.Base().ID
+		}
+		if got != w {
+			t.Fatalf("message %d should be %s but is: %s", i, w, got)
+		}
+	}
+
+	cancel()
+	for range messages {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("SubscribeTransactions() should stop with context.Canceled but stopped with: %v", err)
+	}
+}
+
+func TestUnmarshalTransaction(t *testing.T) {
+	tx, err := oanda.UnmarshalTransaction([]byte(`{"id":"5","type":"ORDER_FILL","instrument":"EUR_USD","units":"-100","tradesClosed":[{"tradeID":"3","units":"-100","realizedPL":"1.25"}]}`))
+	if err != nil {
+		t.Fatalf("UnmarshalTransaction() produced an error: %v", err)
+	}
+	fill, ok := tx.(*oanda.OrderFillTransaction)
+	if !ok {
+		t.Fatalf("UnmarshalTransaction() should return *oanda.OrderFillTransaction but returned: %T", tx)
+	}
+	if fill.ID != "5" || len(fill.TradesClosed) != 1 || fill.TradesClosed[0].RealizedPL != "1.25" {
+		t.Fatalf("UnmarshalTransaction() did not decode every field: %+v", fill)
+	}
+}
@@ -0,0 +1,123 @@
+package oanda_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/davidhintelmann/Oanda-Go/oanda"
+)
+
+func TestGetTransactionsInRange(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/accounts/101-001-1234567-001/transactions":
+			if want := "from=2024-01-01T00%3A00%3A00Z&pageSize=2&type=FUNDING%2CORDER_FILL"; r.URL.RawQuery != want {
+				t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+			}
+			fmt.Fprintf(w, `{"count":3,"pageSize":2,"pages":[
+	"%[1]s/v3/accounts/101-001-1234567-001/transactions/idrange?from=1&to=2",
+	"%[1]s/v3/accounts/101-001-1234567-001/transactions/idrange?from=3&to=3"
+],"lastTransactionID":"3"}`, server.URL)
+		case "/v3/accounts/101-001-1234567-001/transactions/idrange":
+			switch r.URL.Query().Get("from") {
+			case "1":
+				fmt.Fprint(w, `{"transactions":[
+	{"id":"1","type":"CREATE","homeCurrency":"USD","accountNumber":1},
+	{"id":"2","type":"TRANSFER_FUNDS","amount":"1000.0000","fundingReason":"CLIENT_FUNDING","accountBalance":"1000.0000"}
+],"lastTransactionID":"3"}`)
+			default:
+				fmt.Fprint(w, `{"transactions":[{"id":"3","type":"CLIENT_CONFIGURE","alias":"my account"}],"lastTransactionID":"3"}`)
+			}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	list, err := client.GetTransactionsInRange(context.Background(), &oanda.TransactionsRequest{
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		PageSize: 2,
+		Type:     []string{"FUNDING", "ORDER_FILL"},
+	})
+	if err != nil {
+		t.Fatalf("GetTransactionsInRange() produced an error: %v", err)
+	}
+	if len(list.Transactions) != 3 {
+		t.Fatalf("GetTransactionsInRange() should return 3 transactions but returned: %d", len(list.Transactions))
+	}
+
+	if create, ok := list.Transactions[0].(*oanda.CreateTransaction); !ok || create.HomeCurrency != "USD" {
+		t.Errorf("first transaction should be the *oanda.CreateTransaction but is: %+v", list.Transactions[0])
+	}
+	if funds, ok := list.Transactions[1].(*oanda.TransferFundsTransaction); !ok || funds.Amount != "1000.0000" {
+		t.Errorf("second transaction should be the *oanda.TransferFundsTransaction but is: %+v", list.Transactions[1])
+	}
+	if configure, ok := list.Transactions[2].(*oanda.ClientConfigureTransaction); !ok || configure.Alias != "my account" {
+		t.Errorf("third transaction should be the *oanda.ClientConfigureTransaction but is: %+v", list.Transactions[2])
+	}
+}
+
+func TestGetTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/accounts/101-001-1234567-001/transactions/42" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"transaction":{
+	"id": "42", "type": "DIVIDEND_ADJUSTMENT", "instrument": "US30_USD", "dividendAdjustment": "-0.5000",
+	"homeConversionFactors": {"gainQuoteHome": {"factor": "1"}, "lossQuoteHome": {"factor": "1"}},
+	"openTradeDividendAdjustments": [{"tradeID": "7", "dividendAdjustment": "-0.5000"}]
+},"lastTransactionID":"42"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(
+		oanda.WithBaseURL(server.URL),
+		oanda.WithAccountID("101-001-1234567-001"),
+	)
+
+	details, err := client.GetTransaction(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("GetTransaction() produced an error: %v", err)
+	}
+
+	dividend, ok := details.Transaction.(*oanda.DividendAdjustmentTransaction)
+	if !ok {
+		t.Fatalf("GetTransaction() should return *oanda.DividendAdjustmentTransaction but returned: %T", details.Transaction)
+	}
+	if dividend.HomeConversionFactors.GainQuoteHome.Factor != "1" || len(dividend.OpenTradeDividendAdjustments) != 1 {
+		t.Errorf("GetTransaction() did not decode every field: %+v", dividend)
+	}
+}
+
+func TestGetTransactionsIDRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "from=5&to=9&type=ORDER_FILL"; r.URL.RawQuery != want {
+			t.Errorf("query should be %q but is: %q", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"transactions":[{"id":"6","type":"ORDER_FILL","orderID":"5"},{"id":"7","type":"SOMETHING_NEW"}],"lastTransactionID":"9"}`)
+	}))
+	defer server.Close()
+
+	client := oanda.NewClient(oanda.WithBaseURL(server.URL))
+
+	list, err := client.GetTransactionsIDRange(context.Background(), "5", "9", "ORDER_FILL")
+	if err != nil {
+		t.Fatalf("GetTransactionsIDRange() produced an error: %v", err)
+	}
+	if len(list.Transactions) != 2 {
+		t.Fatalf("GetTransactionsIDRange() should return 2 transactions but returned: %d", len(list.Transactions))
+	}
+	if _, ok := list.Transactions[1].(*oanda.UnknownTransaction); !ok {
+		t.Errorf("unknown transaction types should be *oanda.UnknownTransaction but are: %T", list.Transactions[1])
+	}
+}